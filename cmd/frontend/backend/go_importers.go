@@ -9,28 +9,94 @@ import (
 	"path"
 	"path/filepath"
 	"sort"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/sync/singleflight"
 )
 
 var MockCountGoImporters func(ctx context.Context, repo api.RepoName) (int, error)
 
+const (
+	// goImportersCountFreshFor is how long a computed count is served without
+	// being recomputed.
+	goImportersCountFreshFor = 4 * time.Hour
+
+	// goImportersCountMaxStale is how long a count is kept in the cache. Counts
+	// older than goImportersCountFreshFor (but younger than this) are served
+	// while being recomputed in the background.
+	//
+	// Counts are deliberately not warmed ahead of time. Only repositories whose
+	// count was not requested for goImportersCountMaxStale ever wait for a
+	// computation, and warming those would spend godoc.org requests on badges
+	// nobody is looking at.
+	goImportersCountMaxStale = 24 * time.Hour
+
+	// goImportersCountRefreshBackoff is how long stale counts are served
+	// without attempting another refresh after a refresh failed.
+	goImportersCountRefreshBackoff = 15 * time.Minute
+)
+
 var (
-	goImportersCountCache = rcache.NewWithTTL("go-importers-count", 14400) // 4 hours
+	// goImportersCountCache falls back to an in-process cache while Redis is
+	// unreachable, because recomputing a count makes up to 50 requests to
+	// godoc.org.
+	goImportersCountCache = rcache.NewWithTTL("go-importers-count", int(goImportersCountMaxStale/time.Second)).WithLocalFallback(1000)
+
+	// goImportersCountRefreshFailures holds the repositories whose count
+	// failed to refresh within the last goImportersCountRefreshBackoff, so
+	// that views of their stale count do not retry the refresh every time.
+	goImportersCountRefreshFailures = rcache.NewWithTTL("go-importers-count-refresh-failed", int(goImportersCountRefreshBackoff/time.Second))
+
+	// goImportersCountGroup deduplicates concurrent computations of the count
+	// of the same repository, on cache misses as well as background refreshes.
+	goImportersCountGroup singleflight.Group
 
 	countGoImportersHTTPClient *http.Client // mockable in tests
 )
 
+var (
+	goImportersCountStaleCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_go_importers_count_stale_served_total",
+		Help: "Counts Go importers counts served from the cache after they became stale.",
+	})
+	goImportersCountDeduplicatedCounter = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "src_go_importers_count_deduplicated_total",
+		Help: "Counts cache misses for Go importers counts which waited for a computation already in progress instead of starting their own.",
+	})
+	goImportersCountDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_go_importers_count_duration_seconds",
+		Help:    "Go importers count latencies in seconds, by cache outcome (hit, miss or error).",
+		Buckets: []float64{0.01, 0.02, 0.05, 0.1, 0.2, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"outcome"})
+)
+
+func init() {
+	prometheus.MustRegister(goImportersCountStaleCounter)
+	prometheus.MustRegister(goImportersCountDeduplicatedCounter)
+	prometheus.MustRegister(goImportersCountDuration)
+}
+
+// cachedGoImportersCount is the value stored in goImportersCountCache.
+type cachedGoImportersCount struct {
+	Count      int       `json:"count"`
+	ComputedAt time.Time `json:"computedAt"`
+}
+
 // CountGoImporters returns the number of Go importers for the repository's Go subpackages. This is
 // a special case used only on Sourcegraph.com for repository badges.
 //
+// Counts are cached. Once a cached count is older than goImportersCountFreshFor, it is still
+// returned but recomputed in the background, so that callers only wait for the (slow) computation
+// when there is no cached count at all.
+//
 // TODO: The import path is not always the same as the repository name.
 func CountGoImporters(ctx context.Context, repo api.RepoName) (count int, err error) {
 	if MockCountGoImporters != nil {
@@ -43,23 +109,85 @@ func CountGoImporters(ctx context.Context, repo api.RepoName) (count int, err er
 		return 0, errors.New("counting Go importers is not supported on self-hosted instances")
 	}
 
+	start := time.Now()
+	outcome := "miss"
+	defer func() {
+		if err != nil {
+			outcome = "error"
+		}
+		goImportersCountDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+	}()
+
 	cacheKey := string(repo)
-	b, ok := goImportersCountCache.Get(cacheKey)
-	if ok {
-		count, err = strconv.Atoi(string(b))
-		if err == nil {
-			return count, nil // cache hit
+	if b, ok := goImportersCountCache.Get(cacheKey); ok {
+		var cached cachedGoImportersCount
+		if err := json.Unmarshal(b, &cached); err == nil {
+			if time.Since(cached.ComputedAt) >= goImportersCountFreshFor {
+				goImportersCountStaleCounter.Inc()
+				refreshGoImportersCountInBackground(repo)
+			}
+			outcome = "hit"
+			return cached.Count, nil
 		}
 		goImportersCountCache.Delete(cacheKey) // remove unexpectedly invalid cache value
 	}
 
-	defer func() {
-		if err == nil {
-			// Store in cache.
-			goImportersCountCache.Set(cacheKey, []byte(strconv.Itoa(count)))
+	// Concurrent misses for the same repository (e.g., when the count of a popular repository is
+	// first requested) share a single computation. It does not use the ctx of the caller that
+	// started it, so that the other callers still get the count (and it is still cached) if that
+	// caller goes away. Each caller stops waiting once its own ctx is done.
+	started := false
+	ch := goImportersCountGroup.DoChan(cacheKey, func() (interface{}, error) {
+		started = true
+		return computeAndCacheGoImporters(context.Background(), repo)
+	})
+	select {
+	case res := <-ch:
+		if !started {
+			goImportersCountDeduplicatedCounter.Inc()
 		}
-	}()
+		if res.Err != nil {
+			return 0, res.Err
+		}
+		return res.Val.(int), nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
+
+// refreshGoImportersCountInBackground recomputes the cached count for repo,
+// unless a computation is already running or a refresh failed recently. It
+// does not block.
+func refreshGoImportersCountInBackground(repo api.RepoName) {
+	if _, failed := goImportersCountRefreshFailures.Get(string(repo)); failed {
+		return
+	}
+	goImportersCountGroup.DoChan(string(repo), func() (interface{}, error) {
+		count, err := computeAndCacheGoImporters(context.Background(), repo)
+		if err != nil {
+			log15.Warn("Failed to refresh stale Go importers count.", "repo", repo, "error", err)
+			goImportersCountRefreshFailures.Set(string(repo), []byte(err.Error()))
+		}
+		return count, err
+	})
+}
+
+// computeAndCacheGoImporters counts the Go importers of repo and stores the
+// result in goImportersCountCache.
+func computeAndCacheGoImporters(ctx context.Context, repo api.RepoName) (int, error) {
+	count, err := countGoImporters(ctx, repo)
+	if err != nil {
+		return 0, err
+	}
+	b, err := json.Marshal(cachedGoImportersCount{Count: count, ComputedAt: time.Now()})
+	if err != nil {
+		return 0, err
+	}
+	goImportersCountCache.Set(string(repo), b)
+	return count, nil
+}
 
+func countGoImporters(ctx context.Context, repo api.RepoName) (count int, err error) {
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second) // avoid tying up resources unduly
 	defer cancel()
 
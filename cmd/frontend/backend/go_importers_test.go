@@ -3,12 +3,17 @@ package backend
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -23,16 +28,296 @@ func TestCountGoImporters(t *testing.T) {
 	const wantRepoName = "github.com/alice/myrepo"
 
 	rcache.SetupForTest(t)
+	mockGoImportersRepo(t, wantRepoName, mockRoundTripper{
+		response: `{"results":[{"path":"w/x"},{"path":"y/z"}]}`,
+	})
 
-	orig := envvar.SourcegraphDotComMode()
-	envvar.MockSourcegraphDotComMode(true)
-	defer envvar.MockSourcegraphDotComMode(orig) // reset
+	count, err := CountGoImporters(ctx, wantRepoName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 4; /* 2 results (w/x, y/z) * 2 Go packages (d and root) */ count != want {
+		t.Errorf("got count %d, want %d", count, want)
+	}
+}
 
-	mockTransport := mockRoundTripper{
+func TestCountGoImporters_durationByOutcome(t *testing.T) {
+	ctx := testContext()
+	const repoName = "github.com/alice/myrepo"
+
+	rcache.SetupForTest(t)
+	mockGoImportersRepo(t, repoName, mockRoundTripper{
 		response: `{"results":[{"path":"w/x"},{"path":"y/z"}]}`,
+	})
+
+	before := map[string]uint64{}
+	for _, outcome := range []string{"hit", "miss", "error"} {
+		before[outcome] = goImportersCountObservations(t, outcome)
+	}
+
+	// The first call computes the count, and the second one is served from the cache.
+	for i := 0; i < 2; i++ {
+		if _, err := CountGoImporters(ctx, repoName); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rcache.SetupForTest(t)
+	countGoImportersHTTPClient = &http.Client{Transport: mockRoundTripper{response: "not json"}}
+	if _, err := CountGoImporters(ctx, repoName); err == nil {
+		t.Fatal("expected error")
+	}
+
+	for _, outcome := range []string{"hit", "miss", "error"} {
+		if got, want := goImportersCountObservations(t, outcome), before[outcome]+1; got != want {
+			t.Errorf("%s: got %d observations, want %d", outcome, got, want)
+		}
+	}
+}
+
+// goImportersCountObservations returns the number of observations of
+// CountGoImporters latencies with the given outcome.
+func goImportersCountObservations(t *testing.T, outcome string) uint64 {
+	t.Helper()
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != "src_go_importers_count_duration_seconds" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "outcome" && label.GetValue() == outcome {
+					return m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestCountGoImporters_stale(t *testing.T) {
+	ctx := testContext()
+	const repoName = "github.com/alice/myrepo"
+
+	rcache.SetupForTest(t)
+	mockGoImportersRepo(t, repoName, mockRoundTripper{
+		response: `{"results":[{"path":"w/x"},{"path":"y/z"}]}`,
+	})
+
+	b, err := json.Marshal(cachedGoImportersCount{Count: 1, ComputedAt: time.Now().Add(-goImportersCountFreshFor - time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goImportersCountCache.Set(repoName, b)
+
+	// The stale count is served immediately.
+	count, err := CountGoImporters(ctx, repoName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got count %d, want stale count 1", count)
+	}
+
+	// And is replaced by the recomputed count in the background.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		count, err := CountGoImporters(ctx, repoName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count == 4 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("got count %d, want refreshed count 4", count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCountGoImporters_staleRefreshFailed(t *testing.T) {
+	ctx := testContext()
+	const repoName = "github.com/alice/myrepo"
+
+	rcache.SetupForTest(t)
+	transport := &blockingRoundTripper{
+		mockRoundTripper: mockRoundTripper{response: "not json"},
+		started:          make(chan struct{}),
+		release:          make(chan struct{}),
 	}
-	countGoImportersHTTPClient = &http.Client{Transport: mockTransport}
-	defer func() { countGoImportersHTTPClient = nil }()
+	close(transport.release)
+	mockGoImportersRepo(t, repoName, transport)
+
+	b, err := json.Marshal(cachedGoImportersCount{Count: 1, ComputedAt: time.Now().Add(-goImportersCountFreshFor - time.Minute)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	goImportersCountCache.Set(repoName, b)
+
+	if count, err := CountGoImporters(ctx, repoName); err != nil || count != 1 {
+		t.Fatalf("got count %d and error %v, want stale count 1", count, err)
+	}
+	<-transport.started
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, failed := goImportersCountRefreshFailures.Get(repoName); failed {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the failed refresh to be recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	requests := atomic.LoadInt32(&transport.requests)
+
+	// Further views serve the stale count without retrying the refresh.
+	for i := 0; i < 3; i++ {
+		if count, err := CountGoImporters(ctx, repoName); err != nil || count != 1 {
+			t.Fatalf("got count %d and error %v, want stale count 1", count, err)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&transport.requests); got != requests {
+		t.Errorf("got %d requests, want %d (no refresh during the backoff)", got, requests)
+	}
+}
+
+func TestCountGoImporters_concurrentMisses(t *testing.T) {
+	ctx := testContext()
+	const repoName = "github.com/alice/myrepo"
+
+	rcache.SetupForTest(t)
+	transport := &blockingRoundTripper{
+		mockRoundTripper: mockRoundTripper{response: `{"results":[{"path":"w/x"},{"path":"y/z"}]}`},
+		started:          make(chan struct{}),
+		release:          make(chan struct{}),
+	}
+	mockGoImportersRepo(t, repoName, transport)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	counts := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			count, err := CountGoImporters(ctx, repoName)
+			if err != nil {
+				t.Error(err)
+			}
+			counts[i] = count
+		}(i)
+	}
+
+	// Give the other callers time to miss the cache and wait for the first computation.
+	<-transport.started
+	time.Sleep(100 * time.Millisecond)
+	close(transport.release)
+	wg.Wait()
+
+	for i, count := range counts {
+		if count != 4 {
+			t.Errorf("caller %d: got count %d, want 4", i, count)
+		}
+	}
+	// A single computation makes one request per Go package (d and root).
+	if got, want := atomic.LoadInt32(&transport.requests), int32(2); got != want {
+		t.Errorf("got %d requests, want %d (from a single computation)", got, want)
+	}
+}
+
+func TestCountGoImporters_firstCallerCanceled(t *testing.T) {
+	ctx := testContext()
+	const repoName = "github.com/alice/myrepo"
+
+	rcache.SetupForTest(t)
+	transport := &blockingRoundTripper{
+		mockRoundTripper: mockRoundTripper{response: `{"results":[{"path":"w/x"},{"path":"y/z"}]}`},
+		started:          make(chan struct{}),
+		release:          make(chan struct{}),
+	}
+	mockGoImportersRepo(t, repoName, transport)
+
+	// The first caller starts the computation, and goes away while it is running.
+	firstCtx, cancel := context.WithCancel(ctx)
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := CountGoImporters(firstCtx, repoName)
+		firstErr <- err
+	}()
+	<-transport.started
+
+	const callers = 5
+	var wg sync.WaitGroup
+	counts := make([]int, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			count, err := CountGoImporters(ctx, repoName)
+			if err != nil {
+				t.Error(err)
+			}
+			counts[i] = count
+		}(i)
+	}
+
+	// Give the other callers time to wait for the first computation.
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("got error %v for the canceled caller, want %v", err, context.Canceled)
+	}
+	close(transport.release)
+	wg.Wait()
+
+	for i, count := range counts {
+		if count != 4 {
+			t.Errorf("caller %d: got count %d, want 4", i, count)
+		}
+	}
+	if _, ok := goImportersCountCache.Get(repoName); !ok {
+		t.Error("expected the count to be cached")
+	}
+}
+
+// blockingRoundTripper counts requests, and blocks them until release is
+// closed or the request is canceled. It closes started when the first
+// request is made.
+type blockingRoundTripper struct {
+	mockRoundTripper
+	requests int32
+	started  chan struct{}
+	release  chan struct{}
+}
+
+func (t *blockingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if atomic.AddInt32(&t.requests, 1) == 1 {
+		close(t.started)
+	}
+	select {
+	case <-t.release:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	return t.mockRoundTripper.RoundTrip(req)
+}
+
+// mockGoImportersRepo mocks a Go repository on Sourcegraph.com with 2 Go
+// packages, whose importers are served by transport.
+func mockGoImportersRepo(t *testing.T, wantRepoName api.RepoName, transport http.RoundTripper) {
+	t.Helper()
+
+	orig := envvar.SourcegraphDotComMode()
+	envvar.MockSourcegraphDotComMode(true)
+	t.Cleanup(func() { envvar.MockSourcegraphDotComMode(orig) }) // reset
+
+	countGoImportersHTTPClient = &http.Client{Transport: transport}
+	t.Cleanup(func() { countGoImportersHTTPClient = nil })
 
 	Mocks.Repos.GetByName = func(_ context.Context, repoName api.RepoName) (*types.Repo, error) {
 		if repoName != wantRepoName {
@@ -55,14 +340,6 @@ func TestCountGoImporters(t *testing.T) {
 			&util.FileInfo{Name_: "c.go", Mode_: 0},
 		}, nil
 	}
-
-	count, err := CountGoImporters(ctx, wantRepoName)
-	if err != nil {
-		t.Fatal(err)
-	}
-	if want := 4; /* 2 results (w/x, y/z) * 2 Go packages (d and root) */ count != want {
-		t.Errorf("got count %d, want %d", count, want)
-	}
 }
 
 func TestListGoPackagesInRepoImprecise(t *testing.T) {
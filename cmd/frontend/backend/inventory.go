@@ -2,16 +2,21 @@ package backend
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"strconv"
+	"strings"
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
@@ -22,7 +27,9 @@ import (
 // filenames. Enabled by default.
 var useEnhancedLanguageDetection, _ = strconv.ParseBool(env.Get("USE_ENHANCED_LANGUAGE_DETECTION", "true", "Enable more accurate but slower language detection that uses file contents"))
 
-var inventoryCache = rcache.New(fmt.Sprintf("inv:v2:enhanced_%v", useEnhancedLanguageDetection))
+// inventoryCache caches inventories by Git object OID. The version is bumped whenever the way
+// inventories are computed changes (e.g., to exclude generated files).
+var inventoryCache = rcache.New(fmt.Sprintf("inv:v3:enhanced_%v", useEnhancedLanguageDetection))
 
 // InventoryContext returns the inventory context for computing the inventory for the repository at
 // the given commit.
@@ -31,12 +38,29 @@ func InventoryContext(repo gitserver.Repo, commitID api.CommitID, forceEnhancedL
 		return inventory.Context{}, errors.Errorf("refusing to compute inventory for non-absolute commit ID %q", commitID)
 	}
 
+	opts := getInventoryOptions(repo.Name)
+	excludePaths := opts.excludePaths
+
+	// Whether vendored and generated files are included affects every inventory, so include these
+	// options in the cache key unless they are the defaults.
+	var cacheKeySuffix string
+	if opts.includeVendored || opts.includeGenerated {
+		cacheKeySuffix = fmt.Sprintf(":vendored_%v:generated_%v", opts.includeVendored, opts.includeGenerated)
+	}
 	cacheKey := func(e os.FileInfo) string {
 		info, ok := e.Sys().(git.ObjectInfo)
 		if !ok {
 			return "" // not cacheable
 		}
-		return info.OID().String()
+		key := info.OID().String() + cacheKeySuffix
+		if e.Mode().IsDir() && treeContainsExcludedPath(e.Name(), excludePaths) {
+			// Excluded paths are path-dependent, so the inventory of a tree that contains one
+			// depends on where the tree is, not only on its contents. (Identical trees elsewhere,
+			// in this or other repositories, must not share its cache entry.)
+			h := sha256.Sum256([]byte(string(repo.Name) + "\x00" + e.Name() + "\x00" + strings.Join(excludePaths, "\x00")))
+			key += ":" + hex.EncodeToString(h[:8])
+		}
+		return key
 	}
 	invCtx := inventory.Context{
 		ReadTree: func(ctx context.Context, path string) ([]os.FileInfo, error) {
@@ -47,6 +71,16 @@ func InventoryContext(repo gitserver.Repo, commitID api.CommitID, forceEnhancedL
 		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
 			return git.NewFileReader(ctx, repo, commitID, path)
 		},
+		Exclude: func(path string) bool {
+			for _, p := range excludePaths {
+				if path == p || strings.HasPrefix(path, p+"/") {
+					return true
+				}
+			}
+			return false
+		},
+		IncludeVendored:  opts.includeVendored,
+		IncludeGenerated: opts.includeGenerated,
 		CacheGet: func(e os.FileInfo) (inventory.Inventory, bool) {
 			cacheKey := cacheKey(e)
 			if cacheKey == "" {
@@ -86,3 +120,45 @@ func InventoryContext(repo gitserver.Repo, commitID api.CommitID, forceEnhancedL
 
 	return invCtx, nil
 }
+
+// inventoryOptions are the site configuration options which affect the inventory of a repository.
+type inventoryOptions struct {
+	// excludePaths are the paths to exclude, normalized to be relative to the repository root
+	// without a trailing slash.
+	excludePaths []string
+
+	includeVendored  bool
+	includeGenerated bool
+}
+
+// treeContainsExcludedPath reports whether any of excludePaths is beneath the tree at treePath.
+func treeContainsExcludedPath(treePath string, excludePaths []string) bool {
+	treePath = strings.Trim(path.Clean("/"+treePath), "/")
+	for _, p := range excludePaths {
+		if treePath == "" || strings.HasPrefix(p, treePath+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func getInventoryOptions(repo api.RepoName) inventoryOptions {
+	c := conf.Get()
+	if c.ExperimentalFeatures == nil {
+		return inventoryOptions{}
+	}
+
+	var opts inventoryOptions
+	for _, p := range c.ExperimentalFeatures.LanguageStatisticsExcludePaths[string(repo)] {
+		if p = strings.Trim(path.Clean("/"+p), "/"); p != "" {
+			opts.excludePaths = append(opts.excludePaths, p)
+		}
+	}
+	if v := c.ExperimentalFeatures.LanguageStatisticsExcludeVendored; v != nil {
+		opts.includeVendored = !*v
+	}
+	if v := c.ExperimentalFeatures.LanguageStatisticsExcludeGenerated; v != nil {
+		opts.includeGenerated = !*v
+	}
+	return opts
+}
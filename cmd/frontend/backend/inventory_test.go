@@ -0,0 +1,53 @@
+package backend
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGetInventoryOptions(t *testing.T) {
+	f := false
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			LanguageStatisticsExcludeVendored: &f,
+			LanguageStatisticsExcludePaths: map[string][]string{
+				"github.com/a/b": {"/docs/", "dev//ci", "/"},
+			},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	if got, want := getInventoryOptions("github.com/a/b"), (inventoryOptions{
+		excludePaths:    []string{"docs", "dev/ci"},
+		includeVendored: true,
+	}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got, want := getInventoryOptions("github.com/c/d"), (inventoryOptions{includeVendored: true}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTreeContainsExcludedPath(t *testing.T) {
+	excludePaths := []string{"docs", "dev/ci"}
+	for treePath, want := range map[string]bool{
+		"":        true,
+		".":       true,
+		"dev":     true,
+		"dev/ci":  false, // excluded itself, so never inventoried
+		"dev/c":   false,
+		"web":     false,
+		"docs":    false,
+		"docs/ab": false,
+	} {
+		if got := treeContainsExcludedPath(treePath, excludePaths); got != want {
+			t.Errorf("%q: got %v, want %v", treePath, got, want)
+		}
+	}
+	if treeContainsExcludedPath("", nil) {
+		t.Error("want no excluded paths beneath the root without exclusions")
+	}
+}
@@ -154,6 +154,24 @@ func (s *repos) GetInventory(ctx context.Context, repo *types.Repo, commitID api
 	ctx, done := trace(ctx, "Repos", "GetInventory", map[string]interface{}{"repo": repo.Name, "commitID": commitID}, &err)
 	defer done()
 
+	return s.getInventory(ctx, repo, commitID, "", forceEnhancedLanguageDetection)
+}
+
+// GetInventoryForPath returns the inventory of the tree or file at path in the repository at the
+// given commit. Unlike GetInventory, which summarizes the whole repository, it lets callers
+// attribute languages to individual directories and files.
+func (s *repos) GetInventoryForPath(ctx context.Context, repo *types.Repo, commitID api.CommitID, path string, forceEnhancedLanguageDetection bool) (res *inventory.Inventory, err error) {
+	if Mocks.Repos.GetInventoryForPath != nil {
+		return Mocks.Repos.GetInventoryForPath(ctx, repo, commitID, path)
+	}
+
+	ctx, done := trace(ctx, "Repos", "GetInventoryForPath", map[string]interface{}{"repo": repo.Name, "commitID": commitID, "path": path}, &err)
+	defer done()
+
+	return s.getInventory(ctx, repo, commitID, path, forceEnhancedLanguageDetection)
+}
+
+func (s *repos) getInventory(ctx context.Context, repo *types.Repo, commitID api.CommitID, path string, forceEnhancedLanguageDetection bool) (*inventory.Inventory, error) {
 	// Cap GetInventory operation to some reasonable time.
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
 	defer cancel()
@@ -168,7 +186,7 @@ func (s *repos) GetInventory(ctx context.Context, repo *types.Repo, commitID api
 		return nil, err
 	}
 
-	root, err := git.Stat(ctx, *cachedRepo, commitID, "")
+	entry, err := git.Stat(ctx, *cachedRepo, commitID, path)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +195,7 @@ func (s *repos) GetInventory(ctx context.Context, repo *types.Repo, commitID api
 	// tree. Compared to per-blob caching, this creates many fewer cache entries, which means fewer
 	// stores, fewer lookups, and less cache storage overhead. Compared to per-commit caching, this
 	// yields a higher cache hit rate because most trees are unchanged across commits.
-	inv, err := invCtx.Entries(ctx, root)
+	inv, err := invCtx.Entries(ctx, entry)
 	if err != nil {
 		return nil, err
 	}
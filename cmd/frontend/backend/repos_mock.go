@@ -16,12 +16,13 @@ import (
 )
 
 type MockRepos struct {
-	Get          func(v0 context.Context, id api.RepoID) (*types.Repo, error)
-	GetByName    func(v0 context.Context, name api.RepoName) (*types.Repo, error)
-	List         func(v0 context.Context, v1 db.ReposListOptions) ([]*types.Repo, error)
-	GetCommit    func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*git.Commit, error)
-	ResolveRev   func(v0 context.Context, repo *types.Repo, rev string) (api.CommitID, error)
-	GetInventory func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*inventory.Inventory, error)
+	Get                 func(v0 context.Context, id api.RepoID) (*types.Repo, error)
+	GetByName           func(v0 context.Context, name api.RepoName) (*types.Repo, error)
+	List                func(v0 context.Context, v1 db.ReposListOptions) ([]*types.Repo, error)
+	GetCommit           func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*git.Commit, error)
+	ResolveRev          func(v0 context.Context, repo *types.Repo, rev string) (api.CommitID, error)
+	GetInventory        func(v0 context.Context, repo *types.Repo, commitID api.CommitID) (*inventory.Inventory, error)
+	GetInventoryForPath func(v0 context.Context, repo *types.Repo, commitID api.CommitID, path string) (*inventory.Inventory, error)
 }
 
 var errRepoNotFound = &errcode.Mock{
@@ -195,6 +195,23 @@ func TestReposGetInventory(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("path", func(t *testing.T) {
+		rcache.SetupForTest(t)
+
+		inv, err := s.GetInventoryForPath(ctx, &types.Repo{Name: wantRepo}, wantCommitID, "a", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := &inventory.Inventory{
+			Languages: []inventory.Lang{
+				{Name: "Objective-C", TotalBytes: 24, TotalLines: 1},
+			},
+		}
+		if diff := cmp.Diff(want, inv); diff != "" {
+			t.Error(diff)
+		}
+	})
 }
 
 func TestMain(m *testing.M) {
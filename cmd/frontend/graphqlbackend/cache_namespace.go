@@ -0,0 +1,33 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+func (r *schemaResolver) CacheNamespaces(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Cache namespaces are internal details of the instance, so
+	// only admins may list them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return rcache.Namespaces(), nil
+}
+
+func (r *schemaResolver) FlushCacheNamespace(ctx context.Context, args *struct{ Namespace string }) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Flushing caches can cause expensive recomputation, so only
+	// admins may do it.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	log15.Info("Flushing cache namespace (from API request)", "namespace", args.Namespace, "actor", actor.FromContext(ctx))
+	if err := rcache.FlushNamespace(args.Namespace); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,85 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+)
+
+func TestCacheNamespaces(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&schemaResolver{}).CacheNamespaces(ctx)
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	t.Run("authenticated as admin", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+		rcache.New("test_cache_namespaces:abc")
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&schemaResolver{}).CacheNamespaces(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, name := range result {
+			if name == "test_cache_namespaces" {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("expected %q in %v", "test_cache_namespaces", result)
+		}
+	})
+}
+
+func TestFlushCacheNamespace(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&schemaResolver{}).FlushCacheNamespace(ctx, &struct{ Namespace string }{Namespace: "http"})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		_, err := (&schemaResolver{}).FlushCacheNamespace(ctx, &struct{ Namespace string }{Namespace: "does-not-exist"})
+		want := `unknown cache namespace "does-not-exist"`
+		if err == nil || err.Error() != want {
+			t.Fatalf("err: want %q but got %v", want, err)
+		}
+	})
+}
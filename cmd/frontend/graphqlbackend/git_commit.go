@@ -248,13 +248,7 @@ func (r *GitCommitResolver) LanguageStatistics(ctx context.Context) ([]*language
 	if err != nil {
 		return nil, err
 	}
-	stats := make([]*languageStatisticsResolver, 0, len(inventory.Languages))
-	for _, lang := range inventory.Languages {
-		stats = append(stats, &languageStatisticsResolver{
-			l: lang,
-		})
-	}
-	return stats, nil
+	return toLanguageStatisticsResolvers(inventory), nil
 }
 
 func (r *GitCommitResolver) Ancestors(ctx context.Context, args *struct {
@@ -296,6 +296,14 @@ func (r *GitTreeEntryResolver) IsSingleChild(ctx context.Context, args *gitTreeE
 	return len(entries) == 1, nil
 }
 
+func (r *GitTreeEntryResolver) LanguageStatistics(ctx context.Context) ([]*languageStatisticsResolver, error) {
+	inventory, err := backend.Repos.GetInventoryForPath(ctx, r.commit.repoResolver.repo, api.CommitID(r.commit.OID()), r.Path(), false)
+	if err != nil {
+		return nil, err
+	}
+	return toLanguageStatisticsResolvers(inventory), nil
+}
+
 func (r *GitTreeEntryResolver) LSIF(ctx context.Context, args *struct{ ToolName *string }) (GitBlobLSIFDataResolver, error) {
 	codeIntelRequests.WithLabelValues(trace.RequestOrigin(ctx)).Inc()
 
@@ -8,6 +8,7 @@ import (
 	"github.com/graph-gophers/graphql-go/gqltesting"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/db"
@@ -117,3 +118,76 @@ func TestGitTree(t *testing.T) {
 		},
 	})
 }
+
+func TestGitTree_languageStatistics(t *testing.T) {
+	resetMocks()
+	db.Mocks.ExternalServices.List = func(opt db.ExternalServicesListOptions) ([]*types.ExternalService, error) {
+		return nil, nil
+	}
+	db.Mocks.Repos.MockGetByName(t, "github.com/gorilla/mux", 2)
+	backend.Mocks.Repos.ResolveRev = func(ctx context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return exampleCommitSHA1, nil
+	}
+	backend.Mocks.Repos.MockGetCommit_Return_NoCheck(t, &git.Commit{ID: exampleCommitSHA1})
+	backend.Mocks.Repos.GetInventoryForPath = func(ctx context.Context, repo *types.Repo, commitID api.CommitID, path string) (*inventory.Inventory, error) {
+		if commitID != exampleCommitSHA1 {
+			t.Errorf("got commit %q, want %q", commitID, exampleCommitSHA1)
+		}
+		if want := "foo"; path != want {
+			t.Errorf("got path %q, want %q", path, want)
+		}
+		return &inventory.Inventory{
+			Languages: []inventory.Lang{
+				{Name: "Go", TotalBytes: 100, TotalLines: 10},
+				{Name: "Markdown", TotalBytes: 20, TotalLines: 2},
+			},
+		}, nil
+	}
+	git.Mocks.Stat = func(commit api.CommitID, path string) (os.FileInfo, error) {
+		return &util.FileInfo{Name_: path, Mode_: os.ModeDir}, nil
+	}
+	defer git.ResetMocks()
+
+	gqltesting.RunTests(t, []*gqltesting.Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repository(name: "github.com/gorilla/mux") {
+						commit(rev: "` + exampleCommitSHA1 + `") {
+							tree(path: "foo") {
+								languageStatistics {
+									name
+									totalBytes
+									totalLines
+								}
+							}
+						}
+					}
+				}
+			`,
+			ExpectedResult: `
+{
+  "repository": {
+    "commit": {
+      "tree": {
+        "languageStatistics": [
+          {
+            "name": "Go",
+            "totalBytes": 100,
+            "totalLines": 10
+          },
+          {
+            "name": "Markdown",
+            "totalBytes": 20,
+            "totalLines": 2
+          }
+        ]
+      }
+    }
+  }
+}
+			`,
+		},
+	})
+}
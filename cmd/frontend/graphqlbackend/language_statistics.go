@@ -17,3 +17,13 @@ func (l *languageStatisticsResolver) TotalBytes() float64 {
 func (l *languageStatisticsResolver) TotalLines() int32 {
 	return int32(l.l.TotalLines)
 }
+
+func toLanguageStatisticsResolvers(inv *inventory.Inventory) []*languageStatisticsResolver {
+	stats := make([]*languageStatisticsResolver, 0, len(inv.Languages))
+	for _, lang := range inv.Languages {
+		stats = append(stats, &languageStatisticsResolver{
+			l: lang,
+		})
+	}
+	return stats
+}
@@ -457,6 +457,17 @@ type Mutation {
     """
     reloadSite: EmptyResponse
     """
+    Deletes all values cached in Redis under the given cache namespace, e.g. after a
+    change to the shape of cached values. The namespace must be one used by the frontend
+    (see cacheNamespaces).
+
+    The values of every cache in the namespace are deleted. For example, flushing "gh_repo"
+    deletes the cached repositories of all GitHub connections.
+
+    Only site admins may perform this mutation.
+    """
+    flushCacheNamespace(namespace: String!): EmptyResponse
+    """
     Submits a user satisfaction (NPS) survey.
     """
     submitSurvey(input: SurveySubmissionInput!): EmptyResponse
@@ -2304,6 +2315,14 @@ type Query {
     FOR INTERNAL USE ONLY: Lists all status messages
     """
     statusMessages: [StatusMessage!]!
+    """
+    The cache namespaces used by the frontend, which can be flushed with the flushCacheNamespace
+    mutation. A namespace is the part of a cache's key prefix before the first colon, so caches
+    whose key prefix includes a hash (e.g., of a code host's URL and token) share a namespace.
+
+    Only site admins may perform this query.
+    """
+    cacheNamespaces: [String!]!
 
     """
     Look up a namespace by ID.
@@ -4797,6 +4816,11 @@ type GitTree implements TreeEntry {
         """
         recursiveSingleChild: Boolean = false
     ): Boolean!
+    """
+    Statistics for each language present in this tree (including all of its subtrees). Vendored
+    and generated files are excluded.
+    """
+    languageStatistics: [LanguageStatistics!]!
 
     """
     (experimental) The LSIF API may change substantially in the near future as we
@@ -5064,6 +5088,11 @@ type GitBlob implements TreeEntry & File2 {
         """
         recursiveSingleChild: Boolean = false
     ): Boolean!
+    """
+    Statistics for the language of this blob, or an empty list if the language is unknown or the
+    blob is vendored or generated.
+    """
+    languageStatistics: [LanguageStatistics!]!
 
     """
     (experimental) The LSIF API may change substantially in the near future as we
@@ -6069,6 +6098,23 @@ type Site implements SettingsSubject {
         first: Int
     ): AccessTokenConnection!
     """
+    The security event log, most recent first. Only site admins can access this field.
+    """
+    securityEventLogs(
+        """
+        Returns the first n security events from the list.
+        """
+        first: Int
+        """
+        Only return security events performed by this user.
+        """
+        user: ID
+        """
+        Only return security events with this name.
+        """
+        name: String
+    ): SecurityEventLogConnection!
+    """
     A list of all authentication providers. This information is visible to all viewers and does not contain any
     secret information.
     """
@@ -7879,6 +7925,51 @@ type EventLog {
     timestamp: DateTime!
 }
 
+"""
+A security-relevant operation recorded in the append-only security event log.
+"""
+type SecurityEventLog {
+    """
+    The name of the operation, e.g. AccessTokenCreated.
+    """
+    name: String!
+    """
+    The user who performed the operation, if it was performed by a user that still exists.
+    """
+    user: User
+    """
+    Operation-specific details, as a JSON object.
+    """
+    argument: String!
+    """
+    The Sourcegraph version when the event was recorded.
+    """
+    version: String!
+    """
+    The timestamp when the event was recorded.
+    """
+    timestamp: DateTime!
+}
+
+"""
+A list of security event logs.
+"""
+type SecurityEventLogConnection {
+    """
+    A list of security event logs.
+    """
+    nodes: [SecurityEventLog!]!
+    """
+    The total count of security event logs in the connection. This total count may be larger than the number of
+    nodes in this object when the result is paginated.
+    """
+    totalCount: Int!
+    """
+    Pagination information.
+    """
+    pageInfo: PageInfo!
+}
+
 """
 A list of event logs.
 """
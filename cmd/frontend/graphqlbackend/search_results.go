@@ -1227,17 +1227,7 @@ type searchResultsStats struct {
 func (srs *searchResultsStats) ApproximateResultCount() string { return srs.JApproximateResultCount }
 func (srs *searchResultsStats) Sparkline() []int32             { return srs.JSparkline }
 
-var (
-	searchResultsStatsCache   = rcache.NewWithTTL("search_results_stats", 3600) // 1h
-	searchResultsStatsCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "src_graphql_search_results_stats_cache_hit",
-		Help: "Counts cache hits and misses for search results stats (e.g. sparklines).",
-	}, []string{"type"})
-)
-
-func init() {
-	prometheus.MustRegister(searchResultsStatsCounter)
-}
+var searchResultsStatsCache = rcache.NewWithTTL("search_results_stats", 3600) // 1h
 
 func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats, err error) {
 	// Override user context to ensure that stats for this query are cached
@@ -1255,7 +1245,6 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 	// Check if value is in the cache.
 	jsonRes, ok := searchResultsStatsCache.Get(cacheKey)
 	if ok {
-		searchResultsStatsCounter.WithLabelValues("hit").Inc()
 		if err := json.Unmarshal(jsonRes, &stats); err != nil {
 			return nil, err
 		}
@@ -1264,7 +1253,6 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 	}
 
 	// Calculate value from scratch.
-	searchResultsStatsCounter.WithLabelValues("miss").Inc()
 	attempts := 0
 	var v *SearchResultsResolver
 	for {
@@ -1315,6 +1303,7 @@ func (r *searchResolver) Stats(ctx context.Context) (stats *searchResultsStats,
 			return nil, err
 		}
 		searchResultsStatsCache.Set(cacheKey, jsonRes)
+		searchResultsStatsCache.ObserveFill("stored")
 	}
 	return stats, nil
 }
@@ -0,0 +1,125 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (r *siteResolver) SecurityEventLogs(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	User *graphql.ID
+	Name *string
+}) (*securityEventLogConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins can view the security event log.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	var opt db.SecurityEventLogsListOptions
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	if args.User != nil {
+		userID, err := UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		opt.UserID = userID
+	}
+	if args.Name != nil {
+		opt.Name = db.SecurityEventName(*args.Name)
+	}
+	return &securityEventLogConnectionResolver{opt: opt}, nil
+}
+
+// securityEventLogConnectionResolver resolves a list of security events.
+//
+// 🚨 SECURITY: When instantiating a securityEventLogConnectionResolver value, the caller MUST
+// check permissions.
+type securityEventLogConnectionResolver struct {
+	opt db.SecurityEventLogsListOptions
+
+	// cache results because they are used by multiple fields
+	once   sync.Once
+	events []*db.SecurityEvent
+	err    error
+}
+
+func (r *securityEventLogConnectionResolver) compute(ctx context.Context) ([]*db.SecurityEvent, error) {
+	r.once.Do(func() {
+		opt2 := r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.events, r.err = db.SecurityEventLogs.List(ctx, opt2)
+	})
+	return r.events, r.err
+}
+
+func (r *securityEventLogConnectionResolver) Nodes(ctx context.Context) ([]*securityEventLogResolver, error) {
+	events, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(events) > r.opt.LimitOffset.Limit {
+		events = events[:r.opt.LimitOffset.Limit]
+	}
+
+	l := make([]*securityEventLogResolver, 0, len(events))
+	for _, event := range events {
+		l = append(l, &securityEventLogResolver{event: event})
+	}
+	return l, nil
+}
+
+func (r *securityEventLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := db.SecurityEventLogs.Count(ctx, r.opt)
+	return int32(count), err
+}
+
+func (r *securityEventLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	events, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(events) > r.opt.Limit), nil
+}
+
+type securityEventLogResolver struct {
+	event *db.SecurityEvent
+}
+
+func (r *securityEventLogResolver) Name() string {
+	return string(r.event.Name)
+}
+
+func (r *securityEventLogResolver) User(ctx context.Context) (*UserResolver, error) {
+	if r.event.UserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.event.UserID)
+	if err != nil && errcode.IsNotFound(err) {
+		// Don't throw an error if a user has been deleted.
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *securityEventLogResolver) Argument() string {
+	return string(r.event.Argument)
+}
+
+func (r *securityEventLogResolver) Version() string {
+	return r.event.Version
+}
+
+func (r *securityEventLogResolver) Timestamp() DateTime {
+	return DateTime{Time: r.event.Timestamp}
+}
@@ -0,0 +1,57 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+)
+
+func TestSite_SecurityEventLogs(t *testing.T) {
+	t.Run("authenticated as non-admin", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{}, nil
+		}
+
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&siteResolver{}).SecurityEventLogs(ctx, &struct {
+			graphqlutil.ConnectionArgs
+			User *graphql.ID
+			Name *string
+		}{})
+		if want := backend.ErrMustBeSiteAdmin; err != want {
+			t.Errorf("err: want %q but got %v", want, err)
+		}
+		if result != nil {
+			t.Errorf("result: want nil but got %v", result)
+		}
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		resetMocks()
+		db.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+			return &types.User{ID: 1, SiteAdmin: true}, nil
+		}
+
+		user := MarshalUserID(2)
+		name := string(db.SecurityEventAccessTokenCreated)
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+		result, err := (&siteResolver{}).SecurityEventLogs(ctx, &struct {
+			graphqlutil.ConnectionArgs
+			User *graphql.ID
+			Name *string
+		}{User: &user, Name: &name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := (db.SecurityEventLogsListOptions{UserID: 2, Name: db.SecurityEventAccessTokenCreated}); result.opt != want {
+			t.Errorf("opt: want %+v but got %+v", want, result.opt)
+		}
+	})
+}
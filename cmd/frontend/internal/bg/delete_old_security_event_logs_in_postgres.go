@@ -0,0 +1,29 @@
+package bg
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/sourcegraph/internal/db"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+var securityEventLogsRetention = func() time.Duration {
+	str := env.Get("SRC_SECURITY_EVENT_LOGS_RETENTION", "2232h", "how long to keep entries in the security event log (default 93 days)")
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		log.Fatalln("SRC_SECURITY_EVENT_LOGS_RETENTION:", err)
+	}
+	return d
+}()
+
+func DeleteOldSecurityEventLogsInPostgres(ctx context.Context) {
+	for {
+		if err := db.SecurityEventLogs.DeleteOlderThan(ctx, time.Now().Add(-securityEventLogsRetention)); err != nil {
+			log15.Error("deleting expired rows from security_event_logs table", "error", err)
+		}
+		time.Sleep(time.Hour)
+	}
+}
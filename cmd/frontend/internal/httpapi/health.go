@@ -0,0 +1,129 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+// healthCheckTimeout bounds how long a single dependency health check may take.
+const healthCheckTimeout = 5 * time.Second
+
+// healthCheck checks that a single dependency of the frontend is available.
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// dependencyHealth is the result of a healthCheck.
+type dependencyHealth struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency"`
+}
+
+// defaultHealthChecks returns the health checks for the dependencies of the frontend: Postgres,
+// both Redis instances, and every gitserver instance.
+func defaultHealthChecks(ctx context.Context) []healthCheck {
+	checks := []healthCheck{
+		{name: "postgres", check: func(ctx context.Context) error {
+			return dbconn.Global.PingContext(ctx)
+		}},
+		{name: "redis-cache", check: pingRedis(redispool.DialCache)},
+		{name: "redis-store", check: pingRedis(redispool.DialStore)},
+	}
+	for _, addr := range gitserver.DefaultClient.Addrs(ctx) {
+		addr := addr
+		checks = append(checks, healthCheck{name: "gitserver " + addr, check: func(ctx context.Context) error {
+			return gitserver.DefaultClient.Ping(ctx, addr)
+		}})
+	}
+	return checks
+}
+
+// pingRedis returns a health check which pings the Redis instance dialed by dial. It dials a
+// dedicated connection rather than borrowing one from the pool, because pooled connections have no
+// timeouts. All timeouts are bound to the deadline of ctx, so that an unresponsive instance does
+// not leave anything behind once the check gives up.
+func pingRedis(dial func(...redis.DialOption) (redis.Conn, error)) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		timeout := healthCheckTimeout
+		if deadline, ok := ctx.Deadline(); ok {
+			timeout = time.Until(deadline)
+		}
+		if timeout <= 0 {
+			return context.DeadlineExceeded
+		}
+
+		c, err := dial(redis.DialConnectTimeout(timeout), redis.DialReadTimeout(timeout), redis.DialWriteTimeout(timeout))
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		_, err = c.Do("PING")
+		return err
+	}
+}
+
+// newHealthHandler returns a handler which runs the health checks returned by checks concurrently
+// and reports the status and latency of each dependency. It responds with 503 Service Unavailable
+// if any dependency is unhealthy, so that it can be used directly by load balancers.
+func newHealthHandler(checks func(ctx context.Context) []healthCheck) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+		defer cancel()
+
+		var (
+			mu      sync.Mutex
+			wg      sync.WaitGroup
+			results []dependencyHealth
+		)
+		for _, c := range checks(ctx) {
+			wg.Add(1)
+			go func(c healthCheck) {
+				defer wg.Done()
+
+				start := time.Now()
+				err := c.check(ctx)
+				result := dependencyHealth{
+					Name:    c.name,
+					Healthy: err == nil,
+					Latency: time.Since(start).String(),
+				}
+				if err != nil {
+					result.Error = err.Error()
+				}
+
+				mu.Lock()
+				results = append(results, result)
+				mu.Unlock()
+			}(c)
+		}
+		wg.Wait()
+
+		sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+
+		status := http.StatusOK
+		for _, result := range results {
+			if !result.Healthy {
+				status = http.StatusServiceUnavailable
+				break
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(struct {
+			Dependencies []dependencyHealth `json:"dependencies"`
+		}{Dependencies: results})
+	}
+}
@@ -0,0 +1,125 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	failing := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name       string
+		checks     []healthCheck
+		wantStatus int
+		wantErrors map[string]string
+	}{
+		{
+			name:       "healthy",
+			checks:     []healthCheck{{name: "redis", check: ok}, {name: "postgres", check: ok}},
+			wantStatus: http.StatusOK,
+			wantErrors: map[string]string{"postgres": "", "redis": ""},
+		},
+		{
+			name:       "unhealthy",
+			checks:     []healthCheck{{name: "redis", check: failing}, {name: "postgres", check: ok}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantErrors: map[string]string{"postgres": "", "redis": "connection refused"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := newHealthHandler(func(context.Context) []healthCheck { return test.checks })
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest("GET", "/health", nil))
+
+			if rec.Code != test.wantStatus {
+				t.Errorf("got status %d, want %d", rec.Code, test.wantStatus)
+			}
+			var resp struct {
+				Dependencies []dependencyHealth
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Dependencies) != len(test.wantErrors) {
+				t.Fatalf("got %d dependencies, want %d", len(resp.Dependencies), len(test.wantErrors))
+			}
+			// Results are sorted by name.
+			if resp.Dependencies[0].Name != "postgres" {
+				t.Errorf("got first dependency %q, want %q", resp.Dependencies[0].Name, "postgres")
+			}
+			for _, d := range resp.Dependencies {
+				if d.Error != test.wantErrors[d.Name] {
+					t.Errorf("%s: got error %q, want %q", d.Name, d.Error, test.wantErrors[d.Name])
+				}
+				if d.Healthy != (d.Error == "") {
+					t.Errorf("%s: got healthy %v with error %q", d.Name, d.Healthy, d.Error)
+				}
+				if d.Latency == "" {
+					t.Errorf("%s: missing latency", d.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestPingRedis_unresponsive(t *testing.T) {
+	// The listener accepts connections, but never replies.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	dial := func(opts ...redis.DialOption) (redis.Conn, error) {
+		return redis.Dial("tcp", l.Addr().String(), opts...)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := pingRedis(dial)(ctx); err == nil {
+		t.Fatal("expected error pinging unresponsive Redis")
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("ping took %s, want it to give up when ctx is done", d)
+	}
+}
+
+func TestPingRedis_deadlineExceeded(t *testing.T) {
+	dialed := false
+	dial := func(opts ...redis.DialOption) (redis.Conn, error) {
+		dialed = true
+		return nil, errors.New("unexpected dial")
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	if err := pingRedis(dial)(ctx); err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+	if dialed {
+		t.Error("expected no dial once the deadline has passed")
+	}
+}
@@ -16,6 +16,18 @@ type Context struct {
 	// NewFileReader is called to get an io.ReadCloser from the file at path.
 	NewFileReader func(ctx context.Context, path string) (io.ReadCloser, error)
 
+	// Exclude, if set, is called with the path of each tree and file. If it returns true, the tree
+	// (including all of its descendents) or file is omitted from the inventory.
+	Exclude func(path string) bool
+
+	// IncludeVendored, if true, includes vendored trees and files (e.g., node_modules) in the
+	// inventory. By default, they are omitted.
+	IncludeVendored bool
+
+	// IncludeGenerated, if true, includes generated files (e.g., *.pb.go, or files with a "Code
+	// generated ... DO NOT EDIT." header) in the inventory. By default, they are omitted.
+	IncludeGenerated bool
+
 	// CacheGet, if set, returns the cached inventory and true for the given tree, or false for a cache miss.
 	CacheGet func(os.FileInfo) (Inventory, bool)
 
@@ -6,6 +6,7 @@ import (
 	"sort"
 
 	"github.com/pkg/errors"
+	"github.com/src-d/enry/v2"
 )
 
 // fileReadBufferSize is the size of the buffer we'll use while reading file contents
@@ -24,6 +25,10 @@ func (c *Context) Entries(ctx context.Context, entries ...os.FileInfo) (inv Inve
 func (c *Context) entries(ctx context.Context, entries []os.FileInfo, buf []byte) (Inventory, error) {
 	invs := make([]Inventory, len(entries))
 	for i, entry := range entries {
+		if c.excluded(entry) {
+			continue
+		}
+
 		var f func(context.Context, os.FileInfo, []byte) (Inventory, error)
 		switch {
 		case entry.Mode().IsRegular():
@@ -66,12 +71,16 @@ func (c *Context) tree(ctx context.Context, tree os.FileInfo, buf []byte) (inv I
 	}
 	invs := make([]Inventory, len(entries))
 	for i, e := range entries {
+		if c.excluded(e) {
+			continue
+		}
+
 		switch {
 		case e.Mode().IsRegular(): // file
 			// Don't individually cache files that we found during tree traversal. The hit rate for
 			// those cache entries is likely to be much lower than cache entries for files whose
 			// inventory was directly requested.
-			lang, err := getLang(ctx, e, buf, c.NewFileReader)
+			lang, err := getLang(ctx, e, buf, c.NewFileReader, !c.IncludeGenerated)
 			if err != nil {
 				return Inventory{}, errors.Wrapf(err, "inventory file %q", e.Name())
 			}
@@ -91,6 +100,26 @@ func (c *Context) tree(ctx context.Context, tree os.FileInfo, buf []byte) (inv I
 	return Sum(invs), nil
 }
 
+// excluded reports whether the tree or file should be omitted from the inventory. Files whose
+// contents mark them as generated are omitted by getLang instead, because that requires reading
+// them.
+func (c *Context) excluded(e os.FileInfo) bool {
+	if !c.IncludeVendored {
+		// Skip vendored trees (e.g., node_modules) entirely instead of reading every file in them
+		// only to discard it.
+		if e.Mode().IsDir() && enry.IsVendor(e.Name()+"/") {
+			return true
+		}
+		if e.Mode().IsRegular() && enry.IsVendor(e.Name()) {
+			return true
+		}
+	}
+	if !c.IncludeGenerated && e.Mode().IsRegular() && isGeneratedName(e.Name()) {
+		return true
+	}
+	return c.Exclude != nil && c.Exclude(e.Name())
+}
+
 // file computes the inventory of a single file. It caches the result.
 func (c *Context) file(ctx context.Context, file os.FileInfo, buf []byte) (inv Inventory, err error) {
 	// Get and set from the cache.
@@ -107,7 +136,7 @@ func (c *Context) file(ctx context.Context, file os.FileInfo, buf []byte) (inv I
 		}()
 	}
 
-	lang, err := getLang(ctx, file, buf, c.NewFileReader)
+	lang, err := getLang(ctx, file, buf, c.NewFileReader, !c.IncludeGenerated)
 	if err != nil {
 		return Inventory{}, errors.Wrapf(err, "inventory file %q", file.Name())
 	}
@@ -115,3 +115,113 @@ func TestContext_Entries(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestContext_Entries_excluded(t *testing.T) {
+	var readTreeCalls, newFileReaderCalls []string
+	c := Context{
+		ReadTree: func(ctx context.Context, path string) ([]os.FileInfo, error) {
+			readTreeCalls = append(readTreeCalls, path)
+			switch path {
+			case "d":
+				return []os.FileInfo{
+					&util.FileInfo{Name_: "d/node_modules", Mode_: os.ModeDir},
+					&util.FileInfo{Name_: "d/testdata", Mode_: os.ModeDir},
+					&util.FileInfo{Name_: "d/a.go", Size_: 12},
+					&util.FileInfo{Name_: "d/a.pb.go", Size_: 12},
+					&util.FileInfo{Name_: "d/b.go", Size_: 12},
+				}, nil
+			default:
+				panic("unhandled mock ReadTree " + path)
+			}
+		},
+		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			newFileReaderCalls = append(newFileReaderCalls, path)
+			var data []byte
+			switch path {
+			case "d/a.go":
+				data = []byte("package main")
+			case "d/a.pb.go":
+				data = []byte("package main")
+			case "d/b.go":
+				data = []byte("// Code generated by foo. DO NOT EDIT.\npackage main")
+			default:
+				panic("unhandled mock ReadFile " + path)
+			}
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+		Exclude: func(path string) bool {
+			return path == "d/testdata" || path == "e.go"
+		},
+	}
+
+	inv, err := c.Entries(context.Background(),
+		&util.FileInfo{Name_: "d", Mode_: os.ModeDir},
+		&util.FileInfo{Name_: "e.go", Size_: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Inventory{
+		Languages: []Lang{
+			{Name: "Go", TotalBytes: 12, TotalLines: 1},
+		},
+	}); !reflect.DeepEqual(inv, want) {
+		t.Fatalf("got  %#v\nwant %#v", inv, want)
+	}
+
+	// Excluded and vendored trees are not read at all, and neither are files that are generated
+	// according to their name.
+	if want := []string{"d"}; !reflect.DeepEqual(readTreeCalls, want) {
+		t.Errorf("ReadTree calls: got %q, want %q", readTreeCalls, want)
+	}
+	if want := []string{"d/a.go", "d/b.go"}; !reflect.DeepEqual(newFileReaderCalls, want) {
+		t.Errorf("GetFileReader calls: got %q, want %q", newFileReaderCalls, want)
+	}
+}
+
+func TestContext_Entries_includeVendoredAndGenerated(t *testing.T) {
+	c := Context{
+		ReadTree: func(ctx context.Context, path string) ([]os.FileInfo, error) {
+			switch path {
+			case "d":
+				return []os.FileInfo{
+					&util.FileInfo{Name_: "d/node_modules", Mode_: os.ModeDir},
+					&util.FileInfo{Name_: "d/a.pb.go", Size_: 12},
+					&util.FileInfo{Name_: "d/b.go", Size_: 12},
+				}, nil
+			case "d/node_modules":
+				return []os.FileInfo{
+					&util.FileInfo{Name_: "d/node_modules/c.go", Size_: 12},
+				}, nil
+			default:
+				panic("unhandled mock ReadTree " + path)
+			}
+		},
+		NewFileReader: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			var data []byte
+			switch path {
+			case "d/a.pb.go", "d/node_modules/c.go":
+				data = []byte("package main")
+			case "d/b.go":
+				data = []byte("// Code generated by foo. DO NOT EDIT.\npackage main")
+			default:
+				panic("unhandled mock ReadFile " + path)
+			}
+			return ioutil.NopCloser(bytes.NewReader(data)), nil
+		},
+		IncludeVendored:  true,
+		IncludeGenerated: true,
+	}
+
+	inv, err := c.Entries(context.Background(), &util.FileInfo{Name_: "d", Mode_: os.ModeDir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Inventory{
+		Languages: []Lang{
+			{Name: "Go", TotalBytes: 75, TotalLines: 4},
+		},
+	}); !reflect.DeepEqual(inv, want) {
+		t.Fatalf("got  %#v\nwant %#v", inv, want)
+	}
+}
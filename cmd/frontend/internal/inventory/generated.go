@@ -0,0 +1,62 @@
+package inventory
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// generatedFileSuffixes are file name suffixes of files which are almost always generated by a
+// tool (e.g., minifiers, protobuf compilers, or package managers) rather than written by hand.
+var generatedFileSuffixes = []string{
+	".min.js",
+	".min.css",
+	".pb.go",
+	".pb.gw.go",
+	".pb.cc",
+	".pb.h",
+	"_pb2.py",
+	"_pb2_grpc.py",
+	".designer.cs",
+}
+
+// generatedFileNames are the base names of files which are always generated.
+var generatedFileNames = map[string]struct{}{
+	"package-lock.json": {},
+	"yarn.lock":         {},
+	"Cargo.lock":        {},
+	"Gopkg.lock":        {},
+}
+
+// generatedHeaderSize is how many bytes at the start of a file are searched for a generated code
+// marker. Markers are conventionally placed in the file header, and limiting the search avoids
+// matching marker strings that appear later in hand-written code (such as in code generators).
+const generatedHeaderSize = 1024
+
+// generatedMarker matches the generated code markers used by common tools: the Go convention
+// ("Code generated ... DO NOT EDIT."), "@generated", and Visual Studio's "<auto-generated>".
+var generatedMarker = regexp.MustCompile(`(?m)^\W*(Code generated .* DO NOT EDIT\.|@generated\b|<auto-generated)`)
+
+// isGeneratedName reports whether the file at name is generated, based on its name alone. It lets
+// callers skip generated files without reading them. Generated files are excluded from the
+// inventory so that they don't skew language statistics.
+func isGeneratedName(name string) bool {
+	base := path.Base(name)
+	if _, ok := generatedFileNames[base]; ok {
+		return true
+	}
+	for _, suffix := range generatedFileSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGeneratedMarker reports whether the initial bytes of a file contain a generated code marker.
+func hasGeneratedMarker(head []byte) bool {
+	if len(head) > generatedHeaderSize {
+		head = head[:generatedHeaderSize]
+	}
+	return generatedMarker.Match(head)
+}
@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsGeneratedName(t *testing.T) {
+	tests := map[string]bool{
+		"a.go":            false,
+		"a/b.pb.go":       true,
+		"dist/app.min.js": true,
+		"web/yarn.lock":   true,
+		"myyarn.lock.go":  false,
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isGeneratedName(name); got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestHasGeneratedMarker(t *testing.T) {
+	tests := map[string]struct {
+		head string
+		want bool
+	}{
+		"plain go":  {head: "package a\n", want: false},
+		"go marker": {head: "// Code generated by go-bindata. DO NOT EDIT.\n\npackage a\n", want: true},
+		"go marker after license": {
+			head: "// Copyright 2020 Foo\n\n// Code generated by stringer; DO NOT EDIT.\n\npackage a\n",
+			want: true,
+		},
+		"python marker": {head: "# Code generated by foo. DO NOT EDIT.\n", want: true},
+		"@generated":    {head: "/**\n * @generated\n */\n", want: true},
+		"auto-generated": {
+			head: "//------\n// <auto-generated>\n//     This code was generated by a tool.\n",
+			want: true,
+		},
+		"marker in string": {
+			head: `package gen` + "\n\n" + `const header = "// Code generated by gen. DO NOT EDIT."` + "\n",
+			want: false,
+		},
+		"marker past header": {
+			head: "package a\n" + strings.Repeat("\n", generatedHeaderSize) + "// Code generated by foo. DO NOT EDIT.\n",
+			want: false,
+		},
+	}
+	for label, test := range tests {
+		t.Run(label, func(t *testing.T) {
+			if got := hasGeneratedMarker([]byte(test.head)); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
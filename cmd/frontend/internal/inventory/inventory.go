@@ -36,11 +36,13 @@ type Lang struct {
 
 var newLine = []byte{'\n'}
 
-func getLang(ctx context.Context, file os.FileInfo, buf []byte, getFileReader func(ctx context.Context, path string) (io.ReadCloser, error)) (Lang, error) {
+// getLang computes the language statistics of a single file. If skipGenerated is true, files whose
+// initial contents contain a generated code marker are skipped.
+func getLang(ctx context.Context, file os.FileInfo, buf []byte, getFileReader func(ctx context.Context, path string) (io.ReadCloser, error), skipGenerated bool) (Lang, error) {
 	if file == nil {
 		return Lang{}, nil
 	}
-	if !file.Mode().IsRegular() || enry.IsVendor(file.Name()) {
+	if !file.Mode().IsRegular() {
 		return Lang{}, nil
 	}
 	rc, err := getFileReader(ctx, file.Name())
@@ -63,30 +65,37 @@ func getLang(ctx context.Context, file os.FileInfo, buf []byte, getFileReader fu
 		return lang, nil
 	}
 
+	// Read the initial file data, which is needed to detect generated files and (if the filename
+	// was inconclusive) the language.
+	n, err := io.ReadFull(rc, buf)
+	if err == io.EOF {
+		// No bytes read, indicating an empty file
+		if safe {
+			return Lang{Name: matchedLang}, nil
+		}
+		return Lang{}, nil
+	}
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return lang, errors.Wrap(err, "reading initial file data")
+	}
+	if skipGenerated && hasGeneratedMarker(buf[:n]) {
+		return Lang{}, nil
+	}
 	if !safe {
 		// Detect language from content
-		n, err := io.ReadFull(rc, buf)
-		if err == io.EOF {
-			// No bytes read, indicating an empty file
-			return Lang{}, nil
-		}
-		if err != nil && err != io.ErrUnexpectedEOF {
-			return lang, errors.Wrap(err, "reading initial file data")
-		}
 		matchedLang = enry.GetLanguage(file.Name(), buf[:n])
-		lang.TotalBytes += uint64(n)
-		lang.TotalLines += uint64(bytes.Count(buf[:n], newLine))
-		lang.Name = matchedLang
-		if err == io.ErrUnexpectedEOF {
-			// File is smaller than buf, we can exit early
-			if !bytes.HasSuffix(buf[:n], newLine) {
-				// Add final line
-				lang.TotalLines++
-			}
-			return lang, nil
-		}
 	}
 	lang.Name = matchedLang
+	lang.TotalBytes += uint64(n)
+	lang.TotalLines += uint64(bytes.Count(buf[:n], newLine))
+	if err == io.ErrUnexpectedEOF {
+		// File is smaller than buf, we can exit early
+		if !bytes.HasSuffix(buf[:n], newLine) {
+			// Add final line
+			lang.TotalLines++
+		}
+		return lang, nil
+	}
 
 	lineCount, byteCount, err := countLines(rc, buf)
 	if err != nil {
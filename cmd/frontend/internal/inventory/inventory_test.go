@@ -65,7 +65,8 @@ func TestGetLang_language(t *testing.T) {
 			lang, err := getLang(context.Background(),
 				test.file,
 				make([]byte, fileReadBufferSize),
-				makeFileReader(context.Background(), test.file.Path, test.file.Contents))
+				makeFileReader(context.Background(), test.file.Path, test.file.Contents),
+				true)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -129,7 +130,7 @@ func TestGet_readFile(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.file.Name(), func(t *testing.T) {
 			fr := makeFileReader(context.Background(), test.file.(fi).Path, test.file.(fi).Contents)
-			lang, err := getLang(context.Background(), test.file, make([]byte, fileReadBufferSize), fr)
+			lang, err := getLang(context.Background(), test.file, make([]byte, fileReadBufferSize), fr, true)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -164,7 +165,7 @@ func BenchmarkGetLang(b *testing.B) {
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
 		for _, file := range files {
-			_, err = getLang(context.Background(), file, buf, fr)
+			_, err = getLang(context.Background(), file, buf, fr, true)
 			if err != nil {
 				b.Fatal(err)
 			}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+// cloneFilter is the object filter (see the --filter option of git rev-list) used to create partial
+// clones, e.g. "blob:none" to clone commits and trees only, or "blob:limit=1m" to skip large blobs.
+// Cloning less makes the initial clone of huge repositories much faster and smaller on disk.
+//
+// Objects that are filtered out are fetched from the code host on demand by git itself the first
+// time a command needs them, using the repository's origin remote. Commits that are missing
+// altogether are still fetched by ensureRevision. Shallow clones are deliberately not supported,
+// since commands such as git log and git blame need the full history.
+//
+// The filter only applies to clones. Updates fetch from an explicit URL rather than the promisor
+// remote, so the objects of new commits are fetched in full. Passing the filter to those fetches
+// would register the URL (including any credentials) as an additional promisor remote.
+var cloneFilter = env.Get("SRC_GITSERVER_CLONE_FILTER", "", "EXPERIMENTAL: object filter used to create partial clones (e.g. blob:none). Filtered objects are fetched on demand.")
+
+// cloneCmd returns the command which mirrors the repository at url into tmpPath.
+func cloneCmd(ctx context.Context, url, tmpPath string) *exec.Cmd {
+	args := append([]string{"clone", "--mirror", "--progress"}, cloneFilterArgs()...)
+	return exec.CommandContext(ctx, "git", append(args, url, tmpPath)...)
+}
+
+// cloneFilterArgs returns the arguments which make a clone (or the initial fetch of a clone) partial.
+func cloneFilterArgs() []string {
+	if cloneFilter == "" {
+		return nil
+	}
+	return []string{"--filter=" + cloneFilter}
+}
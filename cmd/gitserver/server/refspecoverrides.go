@@ -44,7 +44,9 @@ func refspecOverridesCloneCmd(ctx context.Context, url, tmpPath string) (*exec.C
 			return nil, errors.Wrapf(err, "clone setup failed")
 		}
 	}
-	cmd := exec.CommandContext(ctx, "git", "fetch", "--progress")
+	// Fetching with a filter from the origin remote registers it as the
+	// promisor remote, just like git clone --filter does.
+	cmd := exec.CommandContext(ctx, "git", append([]string{"fetch", "--progress"}, cloneFilterArgs()...)...)
 	cmd.Dir = tmpPath
 	return cmd, nil
 }
@@ -850,7 +850,7 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, url string, o
 				return err
 			}
 		} else {
-			cmd = exec.CommandContext(ctx, "git", "clone", "--mirror", "--progress", url, tmpPath)
+			cmd = cloneCmd(ctx, url, tmpPath)
 		}
 		// see issue #7322: skip LFS content in repositories with Git LFS configured
 		cmd.Env = append(os.Environ(), "GIT_LFS_SKIP_SMUDGE=1")
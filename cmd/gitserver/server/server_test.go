@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -432,6 +433,104 @@ func TestCloneRepo(t *testing.T) {
 	}
 }
 
+// partialCloneTestRepo returns a remote repository which allows filtered clones,
+// and a Server which clones it with cloneFilter set to blob:none.
+func partialCloneTestRepo(t *testing.T) (remote string, s *Server) {
+	t.Helper()
+
+	remote = tmpDir(t)
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("git", "config", "uploadpack.allowFilter", "true")
+	cmd("sh", "-c", "echo hello world > hello.txt")
+	cmd("git", "add", "hello.txt")
+	cmd("git", "commit", "-m", "hello")
+
+	orig := cloneFilter
+	cloneFilter = "blob:none"
+	t.Cleanup(func() { cloneFilter = orig })
+
+	s = &Server{
+		ReposDir:         tmpDir(t),
+		ctx:              context.Background(),
+		locker:           &RepositoryLocker{},
+		cloneLimiter:     mutablelimiter.New(1),
+		cloneableLimiter: mutablelimiter.New(1),
+		repoUpdateLocks:  make(map[api.RepoName]*locks),
+	}
+	// Filters are only honoured by the file:// transport, not by local clones.
+	if _, err := s.cloneRepo(context.Background(), "example.com/foo/bar", "file://"+remote, &cloneOptions{Block: true}); err != nil {
+		t.Fatal(err)
+	}
+	return remote, s
+}
+
+// checkPartialClone checks that the clone of example.com/foo/bar in s is a
+// partial clone whose only promisor remote is origin.
+func checkPartialClone(t *testing.T, s *Server) {
+	t.Helper()
+
+	repo := filepath.Dir(string(s.dir(api.RepoName("example.com/foo/bar"))))
+	got := strings.Fields(runCmd(t, repo, "git", "config", "--get-regexp", `^remote\..*\.promisor$`))
+	if want := []string{"remote.origin.promisor", "true"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected a partial clone with origin as the only promisor remote, got %q", got)
+	}
+}
+
+func TestCloneRepo_partialClone(t *testing.T) {
+	_, s := partialCloneTestRepo(t)
+	checkPartialClone(t, s)
+
+	// The blob was filtered out of the clone and is fetched on demand.
+	repo := filepath.Dir(string(s.dir(api.RepoName("example.com/foo/bar"))))
+	if got, want := runCmd(t, repo, "git", "show", "HEAD:hello.txt"), "hello world\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestCloneRepo_partialCloneRefspecOverrides(t *testing.T) {
+	orig := refspecOverrides
+	refspecOverrides = []string{"+refs/heads/*:refs/heads/*"}
+	defer func() { refspecOverrides = orig }()
+
+	_, s := partialCloneTestRepo(t)
+	checkPartialClone(t, s)
+
+	repo := filepath.Dir(string(s.dir(api.RepoName("example.com/foo/bar"))))
+	if got, want := runCmd(t, repo, "git", "show", "HEAD:hello.txt"), "hello world\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRepoUpdate_partialClone(t *testing.T) {
+	remote, s := partialCloneTestRepo(t)
+
+	runCmd(t, remote, "sh", "-c", "echo goodbye > goodbye.txt")
+	runCmd(t, remote, "git", "add", "goodbye.txt")
+	runCmd(t, remote, "git", "commit", "-m", "goodbye")
+	want := runCmd(t, remote, "git", "rev-parse", "HEAD")
+
+	// Updates fetch from the URL rather than the promisor remote.
+	if err := s.doRepoUpdate(context.Background(), "example.com/foo/bar", "file://"+remote); err != nil {
+		t.Fatal(err)
+	}
+	checkPartialClone(t, s)
+
+	repo := filepath.Dir(string(s.dir(api.RepoName("example.com/foo/bar"))))
+	if got := runCmd(t, repo, "git", "rev-parse", "HEAD"); got != want {
+		t.Errorf("got HEAD %q, want %q", got, want)
+	}
+	// Blobs filtered out of the clone are still fetched on demand.
+	for file, want := range map[string]string{"hello.txt": "hello world\n", "goodbye.txt": "goodbye\n"} {
+		if got := runCmd(t, repo, "git", "show", "HEAD:"+file); got != want {
+			t.Errorf("%s: got %q, want %q", file, got, want)
+		}
+	}
+}
+
 func TestRemoveBadRefs(t *testing.T) {
 	dir := tmpDir(t)
 	gitDir := GitDir(filepath.Join(dir, ".git"))
@@ -150,6 +150,11 @@ func (r *Resolver) SetRepositoryPermissionsForUsers(ctx context.Context, args *g
 		return nil, errors.Wrap(err, "set repository pending permissions")
 	}
 
+	db.SecurityEventLogs.LogEvent(ctx, db.SecurityEventRepositoryPermissionsChanged, map[string]interface{}{
+		"repoID":         repoID,
+		"userIDs":        p.UserIDs.ToArray(),
+		"pendingBindIDs": pendingBindIDs,
+	})
 	return &graphqlbackend.EmptyResponse{}, nil
 }
 
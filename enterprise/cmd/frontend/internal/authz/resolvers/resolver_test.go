@@ -197,14 +197,24 @@ func TestResolver_SetRepositoryPermissionsForUsers(t *testing.T) {
 				}
 				return nil
 			}
+			var securityEvents []*db.SecurityEvent
+			db.Mocks.SecurityEventLogs.Insert = func(_ context.Context, e *db.SecurityEvent) error {
+				securityEvents = append(securityEvents, e)
+				return nil
+			}
 			defer func() {
 				db.Mocks.UserEmails = db.MockUserEmails{}
 				db.Mocks.Users = db.MockUsers{}
 				db.Mocks.Repos = db.MockRepos{}
+				db.Mocks.SecurityEventLogs = db.MockSecurityEventLogs{}
 				edb.Mocks.Perms = edb.MockPerms{}
 			}()
 
 			gqltesting.RunTests(t, test.gqlTests)
+
+			if len(securityEvents) != 1 || securityEvents[0].Name != db.SecurityEventRepositoryPermissionsChanged {
+				t.Errorf("expected a single %s security event, got %v", db.SecurityEventRepositoryPermissionsChanged, securityEvents)
+			}
 		})
 	}
 }
@@ -85,6 +85,13 @@ INSERT INTO access_tokens(subject_user_id, scopes, value_sha256, note, creator_u
 	).Scan(&id); err != nil {
 		return 0, "", err
 	}
+
+	SecurityEventLogs.LogEvent(ctx, SecurityEventAccessTokenCreated, map[string]interface{}{
+		"id":            id,
+		"subjectUserID": subjectUserID,
+		"creatorUserID": creatorUserID,
+		"scopes":        scopes,
+	})
 	return id, token, nil
 }
 
@@ -228,7 +235,15 @@ func (s *accessTokens) DeleteByID(ctx context.Context, id int64, subjectUserID i
 	if Mocks.AccessTokens.DeleteByID != nil {
 		return Mocks.AccessTokens.DeleteByID(id, subjectUserID)
 	}
-	return s.delete(ctx, sqlf.Sprintf("id=%d AND subject_user_id=%d", id, subjectUserID))
+	if _, _, err := s.delete(ctx, sqlf.Sprintf("id=%d AND subject_user_id=%d", id, subjectUserID)); err != nil {
+		return err
+	}
+
+	SecurityEventLogs.LogEvent(ctx, SecurityEventAccessTokenDeleted, map[string]interface{}{
+		"id":            id,
+		"subjectUserID": subjectUserID,
+	})
+	return nil
 }
 
 // DeleteByToken deletes an access token given the secret token value itself (i.e., the same value
@@ -238,25 +253,33 @@ func (s *accessTokens) DeleteByToken(ctx context.Context, tokenHexEncoded string
 	if err != nil {
 		return errors.Wrap(err, "AccessTokens.DeleteByToken")
 	}
-	return s.delete(ctx, sqlf.Sprintf("value_sha256=%s", toSHA256Bytes(token)))
+	id, subjectUserID, err := s.delete(ctx, sqlf.Sprintf("value_sha256=%s", toSHA256Bytes(token)))
+	if err != nil {
+		return err
+	}
+
+	// The token value is secret, so only the ID of the deleted token is recorded.
+	SecurityEventLogs.LogEvent(ctx, SecurityEventAccessTokenDeleted, map[string]interface{}{
+		"id":            id,
+		"subjectUserID": subjectUserID,
+	})
+	return nil
 }
 
-func (s *accessTokens) delete(ctx context.Context, cond *sqlf.Query) error {
+// delete deletes the access token matching cond and returns its ID and
+// subject user.
+func (s *accessTokens) delete(ctx context.Context, cond *sqlf.Query) (id int64, subjectUserID int32, err error) {
 	conds := []*sqlf.Query{cond, sqlf.Sprintf("deleted_at IS NULL")}
-	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s)", sqlf.Join(conds, ") AND ("))
+	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s) RETURNING id, subject_user_id", sqlf.Join(conds, ") AND ("))
 
-	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
-	if err != nil {
-		return err
+	err = dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&id, &subjectUserID)
+	if err == sql.ErrNoRows {
+		return 0, 0, ErrAccessTokenNotFound
 	}
-	nrows, err := res.RowsAffected()
 	if err != nil {
-		return err
-	}
-	if nrows == 0 {
-		return ErrAccessTokenNotFound
+		return 0, 0, err
 	}
-	return nil
+	return id, subjectUserID, nil
 }
 
 func toSHA256Bytes(input []byte) []byte {
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -228,6 +229,56 @@ func TestAccessTokens_Lookup(t *testing.T) {
 
 // 🚨 SECURITY: This tests that deleting the subject or creator user of an access token invalidates
 // the token, and that no new access tokens may be created for deleted users.
+func TestAccessTokens_DeleteByToken(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	var events []*SecurityEvent
+	Mocks.SecurityEventLogs.Insert = func(_ context.Context, e *SecurityEvent) error {
+		events = append(events, e)
+		return nil
+	}
+	defer func() { Mocks.SecurityEventLogs = MockSecurityEventLogs{} }()
+
+	subject, err := Users.Create(ctx, NewUser{
+		Email:                 "a@example.com",
+		Username:              "u1",
+		Password:              "p1",
+		EmailVerificationCode: "c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tid0, tv0, err := AccessTokens.Create(ctx, subject.ID, []string{"a"}, "n0", subject.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events = nil
+	if err := AccessTokens.DeleteByToken(ctx, tv0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AccessTokens.Lookup(ctx, tv0, "a"); err == nil {
+		t.Fatal("Lookup: want error looking up deleted token")
+	}
+	if err := AccessTokens.DeleteByToken(ctx, tv0); err != ErrAccessTokenNotFound {
+		t.Errorf("got error %v, want %v", err, ErrAccessTokenNotFound)
+	}
+
+	// The audit record identifies the deleted token.
+	if len(events) != 1 {
+		t.Fatalf("got %d security events, want 1", len(events))
+	}
+	want := fmt.Sprintf(`{"id":%d,"subjectUserID":%d}`, tid0, subject.ID)
+	if events[0].Name != SecurityEventAccessTokenDeleted || string(events[0].Argument) != want {
+		t.Errorf("got event %s %s, want %s %s", events[0].Name, events[0].Argument, SecurityEventAccessTokenDeleted, want)
+	}
+}
+
 func TestAccessTokens_Lookup_deletedUser(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/gchaincl/sqlhooks"
+	"github.com/golang-migrate/migrate/v4"
 	"github.com/inconshreveable/log15"
 	"github.com/lib/pq"
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -70,14 +71,42 @@ func New(dataSource, dbNameSuffix string) (*sql.DB, error) {
 	}
 	registerPrometheusCollector(db, dbNameSuffix)
 	configureConnectionPool(db)
+	if connectionString != buildBaseConnectionString(dataSource) {
+		migrationDataSources.Store(db, buildBaseConnectionString(dataSource))
+	}
 	return db, nil
 }
 
+// migrationDataSources maps handles returned by New whose connections have a
+// statement timeout to the connection string MigrateDB uses instead.
+var migrationDataSources sync.Map
+
 func MigrateDB(db *sql.DB, databaseName string) error {
+	// Migrations (and waiting for another instance to release the migration
+	// lock) can legitimately take longer than SRC_PGSQL_STATEMENT_TIMEOUT, so
+	// they run on a dedicated handle whose session has no statement timeout.
+	if dataSource, ok := migrationDataSources.Load(db); ok {
+		migrationDB, err := openDBWithStartupWait(dataSource.(string))
+		if err != nil {
+			return errors.Wrap(err, "DB not available")
+		}
+		m, err := dbutil.NewMigrate(migrationDB, databaseName)
+		if err != nil {
+			migrationDB.Close()
+			return err
+		}
+		defer m.Close()
+		return doMigrate(m)
+	}
+
 	m, err := dbutil.NewMigrate(db, databaseName)
 	if err != nil {
 		return err
 	}
+	return doMigrate(m)
+}
+
+func doMigrate(m *migrate.Migrate) error {
 	if err := dbutil.DoMigrate(m); err != nil {
 		return errors.Wrap(err, "Failed to migrate the DB. Please contact support@sourcegraph.com for further assistance")
 	}
@@ -93,9 +122,26 @@ var startupTimeout = func() time.Duration {
 	return d
 }()
 
+var statementTimeout = func() time.Duration {
+	str := env.Get("SRC_PGSQL_STATEMENT_TIMEOUT", "0", "abort any PostgreSQL statement that takes longer than this (0 disables the timeout)")
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		log.Fatalln("SRC_PGSQL_STATEMENT_TIMEOUT:", err)
+	}
+	return d
+}()
+
 // buildConnectionString takes either a Postgres connection string or connection URI,
 // normalizes it, and returns a connection string with parameters appended.
 func buildConnectionString(dataSource string) string {
+	connectionString := buildBaseConnectionString(dataSource)
+	if statementTimeout <= 0 || strings.Contains(connectionString, "statement_timeout") {
+		return connectionString
+	}
+	return fmt.Sprintf("%s statement_timeout=%d", connectionString, statementTimeout.Milliseconds())
+}
+
+func buildBaseConnectionString(dataSource string) string {
 	if dataSource == "" {
 		dataSource = defaultDataSource
 	}
@@ -235,19 +281,43 @@ func (h *hook) OnError(ctx context.Context, err error, query string, args ...int
 }
 
 func registerPrometheusCollector(db *sql.DB, dbNameSuffix string) {
-	c := prometheus.NewGaugeFunc(
-		prometheus.GaugeOpts{
-			Namespace: "src",
-			Subsystem: "pgsql" + dbNameSuffix,
-			Name:      "open_connections",
-			Help:      "Number of open connections to pgsql DB, as reported by pgsql.DB.Stats()",
-		},
-		func() float64 {
-			s := db.Stats()
-			return float64(s.OpenConnections)
-		},
+	gauge := func(name, help string, f func(s sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: "src",
+				Subsystem: "pgsql" + dbNameSuffix,
+				Name:      name,
+				Help:      help,
+			},
+			func() float64 { return f(db.Stats()) },
+		)
+	}
+	counter := func(name, help string, f func(s sql.DBStats) float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Namespace: "src",
+				Subsystem: "pgsql" + dbNameSuffix,
+				Name:      name,
+				Help:      help,
+			},
+			func() float64 { return f(db.Stats()) },
+		)
+	}
+
+	prometheus.MustRegister(
+		gauge("open_connections", "Number of open connections to pgsql DB, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return float64(s.OpenConnections) }),
+		gauge("max_open_connections", "Maximum number of open connections to pgsql DB, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }),
+		gauge("in_use_connections", "Number of connections to pgsql DB currently in use, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return float64(s.InUse) }),
+		gauge("idle_connections", "Number of idle connections to pgsql DB, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return float64(s.Idle) }),
+		counter("wait_count_total", "Total number of connections waited for, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return float64(s.WaitCount) }),
+		counter("wait_duration_seconds_total", "Total time blocked waiting for a new connection, as reported by pgsql.DB.Stats()",
+			func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }),
 	)
-	prometheus.MustRegister(c)
 }
 
 // configureConnectionPool sets reasonable sizes on the built in DB queue. By
@@ -262,7 +332,14 @@ func configureConnectionPool(db *sql.DB) {
 			log.Fatalf("SRC_PGSQL_MAX_OPEN is not an int: %s", e)
 		}
 	}
+	maxIdle := maxOpen
+	if e := os.Getenv("SRC_PGSQL_MAX_IDLE"); e != "" {
+		maxIdle, err = strconv.Atoi(e)
+		if err != nil {
+			log.Fatalf("SRC_PGSQL_MAX_IDLE is not an int: %s", e)
+		}
+	}
 	db.SetMaxOpenConns(maxOpen)
-	db.SetMaxIdleConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
 	db.SetConnMaxLifetime(time.Minute)
 }
@@ -1,6 +1,9 @@
 package dbconn
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestBuildConnectionString(t *testing.T) {
 	tests := []struct {
@@ -35,3 +38,33 @@ func TestBuildConnectionString(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildConnectionString_statementTimeout(t *testing.T) {
+	old := statementTimeout
+	statementTimeout = 30 * time.Second
+	t.Cleanup(func() { statementTimeout = old })
+
+	tests := []struct {
+		name                   string
+		dataSource             string
+		wantedConnectionString string
+	}{
+		{
+			name:                   "connection string",
+			dataSource:             "dbname=sourcegraph",
+			wantedConnectionString: "dbname=sourcegraph fallback_application_name=sourcegraph statement_timeout=30000",
+		}, {
+			name:                   "explicit statement_timeout",
+			dataSource:             "dbname=sourcegraph statement_timeout=1000",
+			wantedConnectionString: "dbname=sourcegraph statement_timeout=1000 fallback_application_name=sourcegraph",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildConnectionString(tt.dataSource); got != tt.wantedConnectionString {
+				t.Errorf("buildConnectionString() = %v, want %v", got, tt.wantedConnectionString)
+			}
+		})
+	}
+}
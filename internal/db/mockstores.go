@@ -28,4 +28,6 @@ type MockStores struct {
 	Secrets MockSecrets
 
 	EventLogs MockEventLogs
+
+	SecurityEventLogs MockSecurityEventLogs
 }
@@ -0,0 +1,151 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/pkg/errors"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/version"
+)
+
+// SecurityEventName is the name of a security-relevant operation recorded in
+// the security event log.
+type SecurityEventName string
+
+const (
+	SecurityEventAccessTokenCreated           SecurityEventName = "AccessTokenCreated"
+	SecurityEventAccessTokenDeleted           SecurityEventName = "AccessTokenDeleted"
+	SecurityEventSiteAdminChanged             SecurityEventName = "SiteAdminChanged"
+	SecurityEventRepositoryPermissionsChanged SecurityEventName = "RepositoryPermissionsChanged"
+)
+
+// SecurityEvent is an entry in the append-only security event log.
+type SecurityEvent struct {
+	ID int64
+	// Name is the operation that was performed.
+	Name SecurityEventName
+	// UserID is the user who performed the operation, or 0 for internal actors.
+	UserID int32
+	// Argument holds operation-specific details as a JSON object.
+	Argument json.RawMessage
+	// Version is the Sourcegraph version that recorded the event.
+	Version   string
+	Timestamp time.Time
+}
+
+type securityEventLogs struct{}
+
+// Insert adds an event to the security event log.
+func (*securityEventLogs) Insert(ctx context.Context, e *SecurityEvent) error {
+	if Mocks.SecurityEventLogs.Insert != nil {
+		return Mocks.SecurityEventLogs.Insert(ctx, e)
+	}
+
+	argument := e.Argument
+	if argument == nil {
+		argument = json.RawMessage(`{}`)
+	}
+	timestamp := e.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	_, err := dbconn.Global.ExecContext(
+		ctx,
+		"INSERT INTO security_event_logs(name, user_id, argument, version, timestamp) VALUES($1, $2, $3, $4, $5)",
+		e.Name,
+		e.UserID,
+		argument,
+		version.Version(),
+		timestamp.UTC(),
+	)
+	if err != nil {
+		return errors.Wrap(err, "INSERT")
+	}
+	return nil
+}
+
+// LogEvent records an operation performed by the actor in ctx. The argument is
+// marshaled to JSON. Failures are logged rather than returned so that the
+// audited operation, which has already happened, is not reported as failed.
+func (l *securityEventLogs) LogEvent(ctx context.Context, name SecurityEventName, argument interface{}) {
+	b, err := json.Marshal(argument)
+	if err != nil {
+		log15.Error("marshaling security event argument", "name", name, "error", err)
+		return
+	}
+
+	e := &SecurityEvent{
+		Name:     name,
+		UserID:   actor.FromContext(ctx).UID,
+		Argument: b,
+	}
+	if err := l.Insert(ctx, e); err != nil {
+		log15.Error("recording security event", "name", name, "error", err)
+	}
+}
+
+// SecurityEventLogsListOptions specifies the options for listing security
+// event logs.
+type SecurityEventLogsListOptions struct {
+	// UserID, if non-zero, only includes events performed by this user.
+	UserID int32
+	// Name, if non-empty, only includes events with this name.
+	Name SecurityEventName
+
+	*LimitOffset
+}
+
+func (o SecurityEventLogsListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %d", o.UserID))
+	}
+	if o.Name != "" {
+		conds = append(conds, sqlf.Sprintf("name = %s", o.Name))
+	}
+	return conds
+}
+
+// List returns security events in descending order of timestamp.
+func (*securityEventLogs) List(ctx context.Context, opt SecurityEventLogsListOptions) ([]*SecurityEvent, error) {
+	q := sqlf.Sprintf(
+		"SELECT id, name, user_id, argument, version, timestamp FROM security_event_logs WHERE %s ORDER BY timestamp DESC, id DESC %s",
+		sqlf.Join(opt.sqlConditions(), "AND"),
+		opt.LimitOffset.SQL(),
+	)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*SecurityEvent{}
+	for rows.Next() {
+		var e SecurityEvent
+		if err := rows.Scan(&e.ID, &e.Name, &e.UserID, &e.Argument, &e.Version, &e.Timestamp); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+// Count counts the security events matching opt.
+func (*securityEventLogs) Count(ctx context.Context, opt SecurityEventLogsListOptions) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM security_event_logs WHERE %s", sqlf.Join(opt.sqlConditions(), "AND"))
+	var count int
+	err := dbconn.Global.QueryRowContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...).Scan(&count)
+	return count, err
+}
+
+// DeleteOlderThan deletes all security events recorded before t.
+func (*securityEventLogs) DeleteOlderThan(ctx context.Context, t time.Time) error {
+	_, err := dbconn.Global.ExecContext(ctx, `DELETE FROM security_event_logs WHERE "timestamp" < $1`, t.UTC())
+	return err
+}
@@ -0,0 +1,7 @@
+package db
+
+import "context"
+
+type MockSecurityEventLogs struct {
+	Insert func(ctx context.Context, e *SecurityEvent) error
+}
@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/db/dbtesting"
+)
+
+func TestSecurityEventLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	events := []*SecurityEvent{
+		{Name: SecurityEventAccessTokenCreated, UserID: 1, Argument: json.RawMessage(`{"id":1}`), Timestamp: now.Add(-48 * time.Hour)},
+		{Name: SecurityEventAccessTokenDeleted, UserID: 1, Timestamp: now.Add(-time.Hour)},
+		{Name: SecurityEventSiteAdminChanged, UserID: 2, Timestamp: now},
+	}
+	for _, e := range events {
+		if err := SecurityEventLogs.Insert(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := SecurityEventLogs.Insert(ctx, &SecurityEvent{UserID: 1}); err == nil {
+		t.Error("expected error inserting event without name")
+	}
+
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE security_event_logs SET user_id = 3"); err == nil {
+		t.Error("expected security event logs to be append-only")
+	}
+
+	for _, tc := range []struct {
+		name  string
+		opt   SecurityEventLogsListOptions
+		names []SecurityEventName
+	}{
+		{
+			name:  "all",
+			names: []SecurityEventName{SecurityEventSiteAdminChanged, SecurityEventAccessTokenDeleted, SecurityEventAccessTokenCreated},
+		},
+		{
+			name:  "by user",
+			opt:   SecurityEventLogsListOptions{UserID: 1},
+			names: []SecurityEventName{SecurityEventAccessTokenDeleted, SecurityEventAccessTokenCreated},
+		},
+		{
+			name:  "by name",
+			opt:   SecurityEventLogsListOptions{Name: SecurityEventAccessTokenCreated},
+			names: []SecurityEventName{SecurityEventAccessTokenCreated},
+		},
+		{
+			name:  "limit",
+			opt:   SecurityEventLogsListOptions{LimitOffset: &LimitOffset{Limit: 1}},
+			names: []SecurityEventName{SecurityEventSiteAdminChanged},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := SecurityEventLogs.List(ctx, tc.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []SecurityEventName
+			for _, e := range have {
+				names = append(names, e.Name)
+			}
+			if len(names) != len(tc.names) {
+				t.Fatalf("have %v, want %v", names, tc.names)
+			}
+			for i := range names {
+				if names[i] != tc.names[i] {
+					t.Fatalf("have %v, want %v", names, tc.names)
+				}
+			}
+		})
+	}
+
+	if err := SecurityEventLogs.DeleteOlderThan(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := SecurityEventLogs.Count(ctx, SecurityEventLogsListOptions{}); err != nil {
+		t.Fatal(err)
+	} else if count != 2 {
+		t.Errorf("have %d events after deleting old ones, want 2", count)
+	}
+}
+
+func TestSecurityEventLogs_LogEvent(t *testing.T) {
+	var have *SecurityEvent
+	Mocks.SecurityEventLogs.Insert = func(_ context.Context, e *SecurityEvent) error {
+		have = e
+		return nil
+	}
+	defer func() { Mocks.SecurityEventLogs = MockSecurityEventLogs{} }()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 42})
+	SecurityEventLogs.LogEvent(ctx, SecurityEventSiteAdminChanged, map[string]interface{}{"userID": 7})
+
+	if have == nil {
+		t.Fatal("expected event to be inserted")
+	}
+	if have.Name != SecurityEventSiteAdminChanged || have.UserID != 42 || string(have.Argument) != `{"userID":7}` {
+		t.Errorf("unexpected event %+v", have)
+	}
+}
@@ -16,6 +16,8 @@ var (
 	UserEmails       = &userEmails{}
 	EventLogs        = &eventLogs{}
 
+	SecurityEventLogs = &securityEventLogs{}
+
 	SurveyResponses = &surveyResponses{}
 
 	ExternalAccounts = &userExternalAccounts{}
@@ -523,8 +523,15 @@ func (u *users) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bool)
 	if Mocks.Users.SetIsSiteAdmin != nil {
 		return Mocks.Users.SetIsSiteAdmin(id, isSiteAdmin)
 	}
-	_, err := dbconn.Global.ExecContext(ctx, "UPDATE users SET site_admin=$1 WHERE id=$2", isSiteAdmin, id)
-	return err
+	if _, err := dbconn.Global.ExecContext(ctx, "UPDATE users SET site_admin=$1 WHERE id=$2", isSiteAdmin, id); err != nil {
+		return err
+	}
+
+	SecurityEventLogs.LogEvent(ctx, SecurityEventSiteAdminChanged, map[string]interface{}{
+		"userID":      id,
+		"isSiteAdmin": isSiteAdmin,
+	})
+	return nil
 }
 
 // CheckAndDecrementInviteQuota should be called before the user (identified
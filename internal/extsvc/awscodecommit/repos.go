@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/codecommit"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Repository is an AWS CodeCommit repository.
@@ -55,7 +54,6 @@ func (c *Client) cachedGetRepository(ctx context.Context, arn string) (*Reposito
 	}
 
 	if cached := c.getRepositoryFromCache(ctx, key); cached != nil {
-		reposCacheCounter.WithLabelValues("hit").Inc()
 		if cached.NotFound {
 			return nil, ErrNotFound
 		}
@@ -66,28 +64,19 @@ func (c *Client) cachedGetRepository(ctx context.Context, arn string) (*Reposito
 	if IsNotFound(err) {
 		// Before we do anything, ensure we cache NotFound responses.
 		c.addRepositoryToCache(key, &cachedRepo{NotFound: true})
-		reposCacheCounter.WithLabelValues("notfound").Inc()
+		c.repoCache.ObserveFill("notfound")
 	}
 	if err != nil {
-		reposCacheCounter.WithLabelValues("error").Inc()
+		c.repoCache.ObserveFill("error")
 		return nil, err
 	}
 
 	c.addRepositoryToCache(key, &cachedRepo{Repository: *repo})
-	reposCacheCounter.WithLabelValues("miss").Inc()
+	c.repoCache.ObserveFill("stored")
 
 	return repo, nil
 }
 
-var reposCacheCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
-	Name: "src_repos_awscodecommit_cache_hit",
-	Help: "Counts cache hits and misses for AWS CodeCommit repo metadata.",
-}, []string{"type"})
-
-func init() {
-	prometheus.MustRegister(reposCacheCounter)
-}
-
 type cachedRepo struct {
 	Repository
 
@@ -102,6 +102,19 @@ func TestNewRepoCache(t *testing.T) {
 	})
 }
 
+func TestNewClient_cacheNamespaces(t *testing.T) {
+	uri, _ := url.Parse("https://github.com")
+
+	NewClient(uri, "token0", nil)
+	before := rcache.Namespaces()
+	for i := 1; i <= 10; i++ {
+		NewClient(uri, fmt.Sprintf("token%d", i), nil)
+	}
+	if got := rcache.Namespaces(); !reflect.DeepEqual(got, before) {
+		t.Errorf("got namespaces %v after creating clients with other tokens, want %v", got, before)
+	}
+}
+
 var updateRegex = flag.String("update", "", "Update testdata of tests matching the given regex")
 
 func update(name string) bool {
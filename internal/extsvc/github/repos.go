@@ -11,7 +11,6 @@ import (
 
 	"github.com/inconshreveable/log15"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // SplitRepositoryNameWithOwner splits a GitHub repository's "owner/name" string into "owner" and "name", with
@@ -134,7 +133,6 @@ func (c *Client) getRepositoryByNodeID(ctx context.Context, id string, nocache b
 func (c *Client) cachedGetRepository(ctx context.Context, key string, getRepositoryFromAPI func(ctx context.Context) (repo *Repository, keys []string, err error), nocache bool) (*Repository, error) {
 	if !nocache {
 		if cached := c.getRepositoryFromCache(ctx, key); cached != nil {
-			reposGitHubCacheCounter.WithLabelValues("hit").Inc()
 			if cached.NotFound {
 				return nil, ErrNotFound
 			}
@@ -147,28 +145,19 @@ func (c *Client) cachedGetRepository(ctx context.Context, key string, getReposit
 		// Before we do anything, ensure we cache NotFound responses.
 		// Do this if client is unauthed or authed, it's okay since we're only caching not found responses here.
 		c.addRepositoryToCache(keys, &cachedRepo{NotFound: true})
-		reposGitHubCacheCounter.WithLabelValues("notfound").Inc()
+		c.repoCache.ObserveFill("notfound")
 	}
 	if err != nil {
-		reposGitHubCacheCounter.WithLabelValues("error").Inc()
+		c.repoCache.ObserveFill("error")
 		return nil, err
 	}
 
 	c.addRepositoryToCache(keys, &cachedRepo{Repository: *repo})
-	reposGitHubCacheCounter.WithLabelValues("miss").Inc()
+	c.repoCache.ObserveFill("stored")
 
 	return repo, nil
 }
 
-var reposGitHubCacheCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
-	Name: "src_repos_github_cache_hit",
-	Help: "Counts cache hits and misses for GitHub repo metadata.",
-}, []string{"type"})
-
-func init() {
-	prometheus.MustRegister(reposGitHubCacheCounter)
-}
-
 type cachedRepo struct {
 	Repository
 
@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/peterhellberg/link"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 type Visibility string
@@ -96,7 +95,6 @@ func (c *Client) GetProject(ctx context.Context, op GetProjectOp) (*Project, err
 func (c *Client) cachedGetProject(ctx context.Context, key string, forceFetch bool, getProjectFromAPI func(context.Context) (proj *Project, keys []string, err error)) (*Project, error) {
 	if !forceFetch {
 		if cached := c.getProjectFromCache(ctx, key); cached != nil {
-			projectsGitLabCacheCounter.WithLabelValues("hit").Inc()
 			if cached.NotFound {
 				return nil, ErrNotFound
 			}
@@ -109,28 +107,19 @@ func (c *Client) cachedGetProject(ctx context.Context, key string, forceFetch bo
 		// Before we do anything, ensure we cache NotFound responses.
 		// Do this if client is unauthed or authed, it's okay since we're only caching not found responses here.
 		c.addProjectToCache(keys, &cachedProj{NotFound: true})
-		projectsGitLabCacheCounter.WithLabelValues("notfound").Inc()
+		c.projCache.ObserveFill("notfound")
 	}
 	if err != nil {
-		projectsGitLabCacheCounter.WithLabelValues("error").Inc()
+		c.projCache.ObserveFill("error")
 		return nil, err
 	}
 
 	c.addProjectToCache(keys, &cachedProj{Project: *proj})
-	projectsGitLabCacheCounter.WithLabelValues("miss").Inc()
+	c.projCache.ObserveFill("stored")
 
 	return proj, nil
 }
 
-var projectsGitLabCacheCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
-	Name: "src_projs_gitlab_cache_hit",
-	Help: "Counts cache hits and misses for GitLab project metadata.",
-}, []string{"type"})
-
-func init() {
-	prometheus.MustRegister(projectsGitLabCacheCounter)
-}
-
 type cachedProj struct {
 	Project
 
@@ -424,7 +424,7 @@ func (c *Client) pingAll(ctx context.Context) []error {
 	ch := make(chan error, len(addrs))
 	for _, addr := range addrs {
 		go func(addr string) {
-			ch <- c.ping(ctx, addr)
+			ch <- c.Ping(ctx, addr)
 		}(addr)
 	}
 
@@ -438,7 +438,9 @@ func (c *Client) pingAll(ctx context.Context) []error {
 	return errs
 }
 
-func (c *Client) ping(ctx context.Context, addr string) error {
+// Ping sends a noop request to the gitserver instance at addr and returns an error if it does not
+// respond successfully.
+func (c *Client) Ping(ctx context.Context, addr string) error {
 	req, err := http.NewRequest("GET", "http://"+addr+"/ping", nil)
 	if err != nil {
 		return err
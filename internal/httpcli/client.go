@@ -60,8 +60,9 @@ type Factory struct {
 
 // redisCache is a HTTP cache backed by Redis. The TTL of a week is a balance
 // between caching values for a useful amount of time versus growing the cache
-// too large.
-var redisCache = rcache.NewWithTTL("http", 604800)
+// too large. Cached responses are mostly JSON API responses, which compress
+// well, so responses of 1KiB or more are stored compressed.
+var redisCache = rcache.NewWithTTL("http", 604800).WithCompression(1024)
 
 // NewExternalHTTPClientFactory returns an httpcli.Factory with common options
 // and middleware pre-set for communicating to external services.
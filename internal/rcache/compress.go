@@ -0,0 +1,81 @@
+package rcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/inconshreveable/log15"
+)
+
+// formatGzip is the format byte prepended to values that are stored gzip
+// compressed. Values written before compression was enabled (or below the
+// compression threshold) are stored as-is.
+//
+// Cached values are arbitrary bytes, so the format byte alone cannot tell the
+// two apart. A value is only decoded if it starts with formatGzip followed by
+// the gzip magic number and the remainder gunzips successfully; anything else
+// is returned unmodified. An uncompressed value which happens to start with
+// that prefix would be ambiguous, so encodeValue always compresses such values
+// (even if compression is disabled), which makes decoding them unambiguous.
+const formatGzip byte = 0xff
+
+// gzipMagic is the header every gzip stream starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// WithCompression returns a copy of the cache which gzip compresses values
+// that are at least minSize bytes long before writing them to Redis. Values
+// are transparently decompressed on read, and entries written without
+// compression continue to be readable.
+func (r *Cache) WithCompression(minSize int) *Cache {
+	c := *r
+	c.compressMinSize = minSize
+	return &c
+}
+
+// encodeValue compresses b if compression is enabled and b is large enough to
+// be worth compressing.
+func (r *Cache) encodeValue(b []byte) []byte {
+	if (r.compressMinSize <= 0 || len(b) < r.compressMinSize) && !hasGzipPrefix(b) {
+		return b
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(formatGzip)
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		log15.Warn("rcache: failed to compress value", "error", err)
+		return b
+	}
+	if err := w.Close(); err != nil {
+		log15.Warn("rcache: failed to compress value", "error", err)
+		return b
+	}
+	return buf.Bytes()
+}
+
+// decodeValue reverses encodeValue. Values which do not carry the compressed
+// format byte are returned unmodified.
+func decodeValue(b []byte) []byte {
+	if !hasGzipPrefix(b) {
+		return b
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(b[1:]))
+	if err != nil {
+		return b
+	}
+	defer r.Close()
+
+	decoded, err := ioutil.ReadAll(r)
+	if err != nil {
+		log15.Warn("rcache: failed to decompress value", "error", err)
+		return b
+	}
+	return decoded
+}
+
+// hasGzipPrefix reports whether b starts like a value encoded by encodeValue.
+func hasGzipPrefix(b []byte) bool {
+	return len(b) >= 1+len(gzipMagic) && b[0] == formatGzip && bytes.HasPrefix(b[1:], gzipMagic)
+}
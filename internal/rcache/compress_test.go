@@ -0,0 +1,95 @@
+package rcache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeDecodeValue(t *testing.T) {
+	c := New("some_prefix").WithCompression(10)
+	large := []byte(strings.Repeat("sourcegraph", 100))
+
+	tests := []struct {
+		name       string
+		value      []byte
+		compressed bool
+	}{
+		{name: "empty", value: []byte{}},
+		{name: "below threshold", value: []byte("small")},
+		{name: "above threshold", value: large, compressed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := c.encodeValue(tt.value)
+			if compressed := len(encoded) > 0 && encoded[0] == formatGzip; compressed != tt.compressed {
+				t.Fatalf("got compressed %v, want %v", compressed, tt.compressed)
+			}
+			if tt.compressed && len(encoded) >= len(tt.value) {
+				t.Errorf("expected compressed value to be smaller: %d >= %d", len(encoded), len(tt.value))
+			}
+			if got := decodeValue(encoded); string(got) != string(tt.value) {
+				t.Errorf("got %q, want %q", got, tt.value)
+			}
+		})
+	}
+}
+
+func TestEncodeDecodeValue_ambiguous(t *testing.T) {
+	// A value that looks like an encoded value must round trip, even when
+	// compression is disabled.
+	var buf bytes.Buffer
+	buf.WriteByte(formatGzip)
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte("sourcegraph"))
+	w.Close()
+	value := buf.Bytes()
+
+	for _, c := range []*Cache{New("some_prefix"), New("some_prefix").WithCompression(1 << 20)} {
+		if got := decodeValue(c.encodeValue(value)); !reflect.DeepEqual(got, value) {
+			t.Errorf("got %q, want %q", got, value)
+		}
+	}
+}
+
+func TestDecodeValue_uncompressed(t *testing.T) {
+	for _, v := range [][]byte{
+		nil,
+		[]byte(`{"json":true}`),
+		{formatGzip},
+		{formatGzip, 'a', 'b', 'c'},
+		{formatGzip, gzipMagic[0], gzipMagic[1], 'n', 'o', 'p', 'e'},
+	} {
+		if got := decodeValue(v); !reflect.DeepEqual(got, v) {
+			t.Errorf("got %q, want %q", got, v)
+		}
+	}
+}
+
+func TestCache_compression(t *testing.T) {
+	SetupForTest(t)
+
+	plain := New("some_prefix")
+	c := plain.WithCompression(10)
+	large := strings.Repeat("sourcegraph", 100)
+
+	// Entries written without compression remain readable.
+	plain.Set("old", []byte(large))
+	if got, ok := c.Get("old"); !ok || string(got) != large {
+		t.Errorf("got %q, want %q", got, large)
+	}
+
+	c.Set("a", []byte(large))
+	c.SetMulti([2]string{"b", large}, [2]string{"c", "small"})
+	for _, k := range []string{"a", "b"} {
+		if got, ok := c.Get(k); !ok || string(got) != large {
+			t.Errorf("%s: got %q, want %q", k, got, large)
+		}
+	}
+	if got, exp := c.GetMulti("a", "b", "c"), byteSlices(large, large, "small"); !reflect.DeepEqual(exp, got) {
+		t.Errorf("got %q, want %q", got, exp)
+	}
+}
@@ -0,0 +1,90 @@
+package rcache
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var ttlExtensionCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_rcache_ttl_extensions_total",
+	Help: "Counts TTL extensions of frequently read keys, by namespace.",
+}, []string{"namespace"})
+
+func init() {
+	prometheus.MustRegister(ttlExtensionCounter)
+}
+
+// WithHotKeyExtension returns a copy of the cache which extends the TTL of hot keys. A key is hot
+// if Get finds it at least minHits times within one TTL period; its TTL is then reset, so that
+// popular values are not recomputed while cold values expire normally. To bound staleness, a value
+// is extended at most maxExtensions times before it expires and must be recomputed.
+//
+// Extensions only apply to caches with a TTL, and only to reads through Get.
+func (r *Cache) WithHotKeyExtension(minHits, maxExtensions int) *Cache {
+	c := *r
+	c.hotKeyMinHits = minHits
+	c.hotKeyMaxExtensions = maxExtensions
+	return &c
+}
+
+// Keys of the counters tracking the hits and extensions of a key, relative to the key itself.
+const (
+	hotKeyHitsPrefix       = "__hits:"
+	hotKeyExtensionsPrefix = "__extensions:"
+)
+
+// extendHotKeyScript counts a hit of the key KEYS[1], tracking hits in KEYS[2] and extensions in
+// KEYS[3]. Once the key has been hit ARGV[2] times in the current period, its TTL is reset to
+// ARGV[1] seconds, unless it was already extended ARGV[3] times. It returns 1 if the TTL was
+// extended, and 0 otherwise.
+var extendHotKeyScript = redis.NewScript(3, `
+local hits = redis.call('INCR', KEYS[2])
+if hits == 1 then
+	redis.call('EXPIRE', KEYS[2], ARGV[1])
+end
+if hits < tonumber(ARGV[2]) then
+	return 0
+end
+redis.call('DEL', KEYS[2])
+
+local extensions = redis.call('INCR', KEYS[3])
+redis.call('EXPIRE', KEYS[3], ARGV[1])
+if extensions > tonumber(ARGV[3]) then
+	return 0
+end
+return redis.call('EXPIRE', KEYS[1], ARGV[1])
+`)
+
+// recordHit records that Get found key, extending its TTL if it is hot.
+func (r *Cache) recordHit(c redis.Conn, key string, ttl int) {
+	if r.hotKeyMinHits <= 0 || ttl == 0 {
+		return
+	}
+
+	prefix := r.rkeyPrefix()
+	extended, err := redis.Int(extendHotKeyScript.Do(c,
+		prefix+key, prefix+hotKeyHitsPrefix+key, prefix+hotKeyExtensionsPrefix+key,
+		ttl, r.hotKeyMinHits, r.hotKeyMaxExtensions,
+	))
+	if err != nil {
+		log15.Warn("failed to extend TTL of hot key", "error", err)
+		return
+	}
+	if extended == 1 {
+		ttlExtensionCounter.WithLabelValues(r.metricsNamespace()).Inc()
+	}
+}
+
+// resetHits sends the commands to forget the hits and extensions of key, so that a newly written
+// value starts out cold.
+func (r *Cache) resetHits(c redis.Conn, key string) {
+	if r.hotKeyMinHits <= 0 {
+		return
+	}
+
+	prefix := r.rkeyPrefix()
+	if err := c.Send("DEL", prefix+hotKeyHitsPrefix+key, prefix+hotKeyExtensionsPrefix+key); err != nil {
+		log15.Warn("failed to write redis command to client output buffer", "cmd", "DEL", "error", err)
+	}
+}
@@ -0,0 +1,64 @@
+package rcache
+
+import (
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestCache_hotKeyExtension(t *testing.T) {
+	SetupForTest(t)
+
+	c := NewWithTTL("some_prefix", 100).WithHotKeyExtension(2, 1)
+
+	ttl := func(key string) int {
+		t.Helper()
+		conn := pool.Get()
+		defer conn.Close()
+		ttl, err := redis.Int(conn.Do("TTL", c.rkeyPrefix()+key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ttl
+	}
+	shorten := func(key string) {
+		t.Helper()
+		conn := pool.Get()
+		defer conn.Close()
+		if _, err := conn.Do("EXPIRE", c.rkeyPrefix()+key, 10); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c.Set("a", []byte("b"))
+	shorten("a")
+
+	// The first hit does not make the key hot.
+	c.Get("a")
+	if got := ttl("a"); got > 10 {
+		t.Fatalf("TTL extended after one hit: %d", got)
+	}
+
+	// The second hit extends the TTL.
+	c.Get("a")
+	if got := ttl("a"); got <= 10 {
+		t.Fatalf("TTL not extended after two hits: %d", got)
+	}
+
+	// The key was already extended the maximum number of times.
+	shorten("a")
+	c.Get("a")
+	c.Get("a")
+	if got := ttl("a"); got > 10 {
+		t.Fatalf("TTL extended beyond maxExtensions: %d", got)
+	}
+
+	// Writing the value again resets its extensions.
+	c.Set("a", []byte("c"))
+	shorten("a")
+	c.Get("a")
+	c.Get("a")
+	if got := ttl("a"); got <= 10 {
+		t.Fatalf("TTL not extended after the value was rewritten: %d", got)
+	}
+}
@@ -0,0 +1,149 @@
+package rcache
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/gomodule/redigo/redis"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var tierHitCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_rcache_tier_hits_total",
+	Help: "Counts cache hits for caches with an in-process fallback, by the tier (redis or local) which served them.",
+}, []string{"tier"})
+
+func init() {
+	prometheus.MustRegister(tierHitCounter)
+}
+
+// WithLocalFallback returns a copy of the cache which falls back to an
+// in-process LRU cache holding at most maxEntries values while Redis is
+// unreachable. Reads that fail against Redis are served from the LRU and
+// writes that fail against Redis are stored in it, so callers keep some
+// caching during a Redis outage instead of recomputing every value.
+//
+// The LRU is only consulted when Redis returns an error, or for redisDownFor
+// after a command failed to reach Redis, so values written during an outage
+// are not visible once Redis is reachable again. Like Redis keys, LRU keys
+// include the cache's version, and flushing the namespace also clears the
+// LRU.
+func (r *Cache) WithLocalFallback(maxEntries int) *Cache {
+	c := *r
+	c.local = &localCache{lru: lru.New(maxEntries)}
+
+	localCachesMu.Lock()
+	localCaches[r.keyPrefix] = append(localCaches[r.keyPrefix], c.local)
+	localCachesMu.Unlock()
+
+	return &c
+}
+
+// redisDownFor is how long caches with a local fallback skip Redis after a
+// command failed to reach it. Without it, every read and write during an
+// outage would first wait for Redis to time out.
+const redisDownFor = 5 * time.Second
+
+// redisDownUntil is the time (in Unix nanoseconds) until which caches with a
+// local fallback skip Redis. It is accessed atomically.
+var redisDownUntil int64
+
+// markRedisDown records that err, returned by a Redis command, means Redis
+// is unreachable. Errors replied by Redis itself do not.
+func markRedisDown(err error) {
+	if _, ok := err.(redis.Error); ok || err == redis.ErrNil {
+		return
+	}
+	atomic.StoreInt64(&redisDownUntil, time.Now().Add(redisDownFor).UnixNano())
+}
+
+// skipRedis reports whether the cache should use its local fallback without
+// trying Redis first.
+func (r *Cache) skipRedis() bool {
+	return r.local != nil && time.Now().UnixNano() < atomic.LoadInt64(&redisDownUntil)
+}
+
+// getLocal looks up key in the local fallback.
+func (r *Cache) getLocal(key string) ([]byte, bool) {
+	b, ok := r.local.get(r.rkeyPrefix() + key)
+	r.observeLookup(ok)
+	if ok {
+		tierHitCounter.WithLabelValues("local").Inc()
+	}
+	return b, ok
+}
+
+var (
+	localCachesMu sync.Mutex
+	localCaches   = map[string][]*localCache{} // by key prefix
+)
+
+// clearLocalCaches clears the fallback LRUs of all caches whose key prefix is
+// keyPrefix, or starts with keyPrefix followed by ":".
+func clearLocalCaches(keyPrefix string) {
+	localCachesMu.Lock()
+	defer localCachesMu.Unlock()
+
+	for p, ls := range localCaches {
+		if p = strings.TrimSuffix(p, ":"); p != keyPrefix && !strings.HasPrefix(p, keyPrefix+":") {
+			continue
+		}
+		for _, l := range ls {
+			l.clear()
+		}
+	}
+}
+
+// localCache is a concurrency-safe LRU cache whose entries honour the TTL of
+// the Cache it belongs to.
+type localCache struct {
+	mu  sync.Mutex
+	lru *lru.Cache
+}
+
+type localEntry struct {
+	value     []byte
+	expiresAt time.Time // zero if the entry never expires
+}
+
+func (l *localCache) get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	v, ok := l.lru.Get(key)
+	if !ok {
+		return nil, false
+	}
+	e := v.(localEntry)
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		l.lru.Remove(key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (l *localCache) set(key string, value []byte, ttlSeconds int) {
+	e := localEntry{value: value}
+	if ttlSeconds > 0 {
+		e.expiresAt = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
+	}
+
+	l.mu.Lock()
+	l.lru.Add(key, e)
+	l.mu.Unlock()
+}
+
+func (l *localCache) delete(key string) {
+	l.mu.Lock()
+	l.lru.Remove(key)
+	l.mu.Unlock()
+}
+
+func (l *localCache) clear() {
+	l.mu.Lock()
+	l.lru.Clear()
+	l.mu.Unlock()
+}
@@ -0,0 +1,155 @@
+package rcache
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/gomodule/redigo/redis"
+)
+
+func TestCache_localFallback(t *testing.T) {
+	old := pool
+	pool = &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, errors.New("redis is unavailable")
+		},
+	}
+	t.Cleanup(func() { pool = old })
+
+	c := New("some_prefix").WithLocalFallback(2)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Initial Get should find nothing")
+	}
+
+	c.Set("a", []byte("b"))
+	if b, ok := c.Get("a"); !ok || string(b) != "b" {
+		t.Fatalf("got %q, %v, want %q", b, ok, "b")
+	}
+
+	c.SetMulti([2]string{"k0", "x"}, [2]string{"k1", "y"})
+	if got, exp := c.GetMulti("k0", "k1", "a"), [][]byte{[]byte("x"), []byte("y"), nil}; !reflect.DeepEqual(exp, got) {
+		t.Errorf("Expected %v, but got %v", exp, got)
+	}
+
+	c.Delete("k0")
+	if _, ok := c.Get("k0"); ok {
+		t.Fatal("Get after delete should find nothing")
+	}
+
+	// Caches without a fallback are unaffected.
+	plain := New("some_prefix")
+	plain.Set("a", []byte("b"))
+	if _, ok := plain.Get("a"); ok {
+		t.Fatal("Get without fallback should find nothing")
+	}
+}
+
+func TestCache_localFallbackUnresponsive(t *testing.T) {
+	// Redis does not refuse connections, so every dial waits for the connect
+	// timeout.
+	atomic.StoreInt64(&redisDownUntil, 0)
+	var dials int32
+	old := pool
+	pool = &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			atomic.AddInt32(&dials, 1)
+			time.Sleep(200 * time.Millisecond)
+			return nil, errors.New("dial tcp: i/o timeout")
+		},
+	}
+	t.Cleanup(func() {
+		pool = old
+		atomic.StoreInt64(&redisDownUntil, 0)
+	})
+
+	c := New("some_prefix").WithLocalFallback(10)
+	c.Set("a", []byte("b"))
+
+	// Further commands go straight to the fallback.
+	start := time.Now()
+	for i := 0; i < 10; i++ {
+		if b, ok := c.Get("a"); !ok || string(b) != "b" {
+			t.Fatalf("got %q, %v, want %q", b, ok, "b")
+		}
+	}
+	c.SetMulti([2]string{"k0", "x"})
+	if got, exp := c.GetMulti("k0", "a"), [][]byte{[]byte("x"), []byte("b")}; !reflect.DeepEqual(exp, got) {
+		t.Errorf("Expected %v, but got %v", exp, got)
+	}
+	if d := time.Since(start); d > 100*time.Millisecond {
+		t.Errorf("commands took %s, want them to skip Redis", d)
+	}
+	if got := atomic.LoadInt32(&dials); got != 1 {
+		t.Errorf("got %d dials, want 1", got)
+	}
+
+	// Redis is tried again once redisDownFor has passed.
+	atomic.StoreInt64(&redisDownUntil, 0)
+	c.Get("a")
+	if got := atomic.LoadInt32(&dials); got != 2 {
+		t.Errorf("got %d dials, want 2", got)
+	}
+}
+
+func TestMarkRedisDown(t *testing.T) {
+	atomic.StoreInt64(&redisDownUntil, 0)
+	t.Cleanup(func() { atomic.StoreInt64(&redisDownUntil, 0) })
+	c := New("some_prefix").WithLocalFallback(10)
+
+	markRedisDown(redis.Error("WRONGTYPE Operation against a key holding the wrong kind of value"))
+	if c.skipRedis() {
+		t.Error("expected errors replied by Redis not to skip Redis")
+	}
+	markRedisDown(errors.New("dial tcp: i/o timeout"))
+	if !c.skipRedis() {
+		t.Error("expected connection errors to skip Redis")
+	}
+	if New("some_prefix").skipRedis() {
+		t.Error("expected caches without a fallback not to skip Redis")
+	}
+}
+
+func TestCache_localFallbackVersionAndFlush(t *testing.T) {
+	old := pool
+	pool = &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return nil, errors.New("redis is unavailable")
+		},
+	}
+	t.Cleanup(func() { pool = old })
+
+	c := New("some_prefix").WithLocalFallback(10)
+	v1, v2 := c.WithVersion("1"), c.WithVersion("2")
+
+	v1.Set("a", []byte("1"))
+	if _, ok := v2.Get("a"); ok {
+		t.Fatal("expected value written under another version to be invisible")
+	}
+	if b, ok := v1.Get("a"); !ok || string(b) != "1" {
+		t.Fatalf("got %q, %v, want %q", b, ok, "1")
+	}
+
+	v1.Flush()
+	if _, ok := v1.Get("a"); ok {
+		t.Fatal("expected Flush to clear the local fallback")
+	}
+}
+
+func TestLocalCache_ttl(t *testing.T) {
+	l := &localCache{lru: lru.New(1)}
+
+	l.set("a", []byte("b"), 0)
+	if _, ok := l.get("a"); !ok {
+		t.Fatal("expected entry without TTL to be present")
+	}
+
+	l.lru.Add("a", localEntry{value: []byte("b"), expiresAt: time.Now().Add(-time.Second)})
+	if _, ok := l.get("a"); ok {
+		t.Fatal("expected expired entry to be absent")
+	}
+}
@@ -0,0 +1,129 @@
+package rcache
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	lookupCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_rcache_lookups_total",
+		Help: "Counts cache lookups by namespace and result (hit or miss).",
+	}, []string{"namespace", "result"})
+
+	fillCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_rcache_fills_total",
+		Help: "Counts how callers resolved values missing from the cache, by namespace and result (e.g. stored, notfound or error).",
+	}, []string{"namespace", "result"})
+
+	valueSizeHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_rcache_value_size_bytes",
+		Help:    "Size of values written to the cache, after compression, by namespace.",
+		Buckets: prometheus.ExponentialBuckets(64, 4, 9), // 64B to 4MiB
+	}, []string{"namespace"})
+)
+
+func init() {
+	prometheus.MustRegister(lookupCounter)
+	prometheus.MustRegister(fillCounter)
+	prometheus.MustRegister(valueSizeHistogram)
+}
+
+// metricsNamespace returns the namespace label used for a cache's metrics.
+// Key prefixes often embed a hash or version (e.g. "gh_repo:<hash>"), so only
+// the segment before the first colon is used to keep label cardinality low.
+func (r *Cache) metricsNamespace() string {
+	return namespaceOf(r.keyPrefix)
+}
+
+func (r *Cache) observeLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	lookupCounter.WithLabelValues(r.metricsNamespace(), result).Inc()
+}
+
+// ObserveFill records how the caller resolved a value which was missing from
+// the cache, e.g. "stored" once the value was computed and stored, or
+// "notfound" or "error" if computing it failed. Get and GetMulti already
+// count hits and misses per namespace (src_rcache_lookups_total), so callers
+// should use ObserveFill for any further outcomes instead of maintaining
+// their own hit/miss counters.
+func (r *Cache) ObserveFill(result string) {
+	fillCounter.WithLabelValues(r.metricsNamespace(), result).Inc()
+}
+
+func (r *Cache) observeValueSize(b []byte) {
+	valueSizeHistogram.WithLabelValues(r.metricsNamespace()).Observe(float64(len(b)))
+}
+
+// redisStatsCollector reports the number of keys evicted and expired by the
+// Redis cache instance. Redis does not track these per key prefix, so they
+// are reported for the instance as a whole.
+type redisStatsCollector struct {
+	evicted *prometheus.Desc
+	expired *prometheus.Desc
+}
+
+// NewRedisStatsCollector returns a collector reporting eviction and expiry
+// counts of the Redis instance backing rcache. Every scrape issues an INFO
+// command, so it should only be registered by a single service.
+func NewRedisStatsCollector() prometheus.Collector {
+	return &redisStatsCollector{
+		evicted: prometheus.NewDesc("src_rcache_redis_evicted_keys_total", "Number of keys evicted by the Redis cache due to the maxmemory limit.", nil, nil),
+		expired: prometheus.NewDesc("src_rcache_redis_expired_keys_total", "Number of keys expired by the Redis cache.", nil, nil),
+	}
+}
+
+func (c *redisStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.evicted
+	ch <- c.expired
+}
+
+func (c *redisStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	conn := pool.Get()
+	defer conn.Close()
+
+	info, err := redis.String(conn.Do("INFO", "stats"))
+	if err != nil {
+		log15.Warn("failed to execute redis command", "cmd", "INFO", "error", err)
+		return
+	}
+
+	stats := parseRedisInfo(info)
+	for desc, field := range map[*prometheus.Desc]string{
+		c.evicted: "evicted_keys",
+		c.expired: "expired_keys",
+	} {
+		if v, ok := stats[field]; ok {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, v)
+		}
+	}
+}
+
+// parseRedisInfo parses the numeric fields of the output of the Redis INFO
+// command.
+func parseRedisInfo(info string) map[string]float64 {
+	stats := map[string]float64{}
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.Index(line, ":")
+		if i < 0 {
+			continue
+		}
+		v, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			continue
+		}
+		stats[line[:i]] = v
+	}
+	return stats
+}
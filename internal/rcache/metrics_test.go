@@ -0,0 +1,42 @@
+package rcache
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCache_metricsNamespace(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"http":                 "http",
+		"cc_repo:":             "cc_repo",
+		"gh_repo:aGVsbG8=":     "gh_repo",
+		"inv:v2:enhanced_true": "inv",
+	} {
+		if got := New(prefix).metricsNamespace(); got != want {
+			t.Errorf("for prefix %q, got %q, want %q", prefix, got, want)
+		}
+	}
+}
+
+func TestCache_ObserveFill(t *testing.T) {
+	c := New("gh_repo:aGVsbG8=")
+	before := testutil.ToFloat64(fillCounter.WithLabelValues("gh_repo", "notfound"))
+	c.ObserveFill("notfound")
+	if got := testutil.ToFloat64(fillCounter.WithLabelValues("gh_repo", "notfound")); got != before+1 {
+		t.Errorf("got %v, want %v", got, before+1)
+	}
+}
+
+func TestParseRedisInfo(t *testing.T) {
+	info := "# Stats\r\ntotal_connections_received:12\r\nexpired_keys:3\r\nevicted_keys:0\r\nrole:master\r\n"
+	want := map[string]float64{
+		"total_connections_received": 12,
+		"expired_keys":               3,
+		"evicted_keys":               0,
+	}
+	if got := parseRedisInfo(info); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package rcache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	namespacesMu sync.Mutex
+	namespaces   = map[string]struct{}{}
+)
+
+// namespaceOf returns the namespace of a cache with the given key prefix: the
+// part before the first ":". Some caches include a hash after it (e.g., the
+// repository caches of GitHub clients hash the client's token), so that
+// namespaces, unlike key prefixes, are a small and fixed set.
+func namespaceOf(keyPrefix string) string {
+	if i := strings.Index(keyPrefix, ":"); i >= 0 {
+		return keyPrefix[:i]
+	}
+	return keyPrefix
+}
+
+// registerNamespace records that a cache with the given key prefix was
+// created by this process, so that its namespace can be flushed by name.
+func registerNamespace(keyPrefix string) {
+	namespacesMu.Lock()
+	namespaces[namespaceOf(keyPrefix)] = struct{}{}
+	namespacesMu.Unlock()
+}
+
+// Namespaces returns the sorted namespaces of all caches created by this
+// process.
+func Namespaces() []string {
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FlushNamespace deletes all values of the caches in the given namespace (see
+// Namespaces). Only namespaces of caches created by this process may be
+// flushed, so callers cannot delete arbitrary keys.
+//
+// Keys are matched by prefix, so that values written under any version (see
+// WithVersion) and by every cache in the namespace (e.g., those of all GitHub
+// clients) are deleted.
+func FlushNamespace(namespace string) error {
+	namespacesMu.Lock()
+	_, ok := namespaces[namespace]
+	namespacesMu.Unlock()
+	if !ok {
+		return fmt.Errorf("unknown cache namespace %q", namespace)
+	}
+	return flushNamespace(namespace)
+}
+
+// flushNamespace deletes all values whose key prefix is keyPrefix, or starts
+// with keyPrefix followed by ":".
+func flushNamespace(keyPrefix string) error {
+	keyPrefix = strings.TrimSuffix(keyPrefix, ":")
+	clearLocalCaches(keyPrefix)
+
+	c := pool.Get()
+	defer c.Close()
+
+	// deleteKeysWithPrefix matches prefix+":*".
+	return deleteKeysWithPrefix(c, globalPrefix+":"+keyPrefix)
+}
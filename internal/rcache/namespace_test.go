@@ -0,0 +1,78 @@
+package rcache
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlushNamespace_unknown(t *testing.T) {
+	if err := FlushNamespace("does-not-exist"); err == nil {
+		t.Fatal("expected error flushing an unregistered namespace")
+	}
+}
+
+func TestCache_versionAndFlush(t *testing.T) {
+	SetupForTest(t)
+
+	v1 := New("some_prefix").WithVersion("1")
+	v2 := New("some_prefix").WithVersion("2")
+	other := New("other_prefix")
+
+	v1.Set("a", []byte("1"))
+	if _, ok := v2.Get("a"); ok {
+		t.Fatal("expected value written under another version to be invisible")
+	}
+	v2.Set("a", []byte("2"))
+	other.Set("a", []byte("other"))
+
+	found := false
+	for _, name := range Namespaces() {
+		if name == "some_prefix" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected some_prefix in %v", Namespaces())
+	}
+
+	if err := FlushNamespace("some_prefix"); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []*Cache{v1, v2} {
+		if _, ok := c.Get("a"); ok {
+			t.Errorf("expected version %q to be flushed", c.version)
+		}
+	}
+	if b, ok := other.Get("a"); !ok || string(b) != "other" {
+		t.Errorf("expected other namespace to be untouched, got %q", b)
+	}
+}
+
+func TestFlushNamespace_hashedKeyPrefixes(t *testing.T) {
+	SetupForTest(t)
+
+	// Caches whose key prefix includes a hash (e.g., of a token) share the
+	// namespace before the first ":".
+	a, b := New("hashed_prefix:aaa"), New("hashed_prefix:bbb")
+	other := New("hashed_prefix_other")
+	for _, name := range Namespaces() {
+		if strings.HasPrefix(name, "hashed_prefix:") {
+			t.Fatalf("expected only the namespace of hashed key prefixes in %v", Namespaces())
+		}
+	}
+
+	for _, c := range []*Cache{a, b, other} {
+		c.Set("k", []byte("v"))
+	}
+	if err := FlushNamespace("hashed_prefix"); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []*Cache{a, b} {
+		if _, ok := c.Get("k"); ok {
+			t.Errorf("expected %q to be flushed", c.keyPrefix)
+		}
+	}
+	if _, ok := other.Get("k"); !ok {
+		t.Error("expected other namespace to be untouched")
+	}
+}
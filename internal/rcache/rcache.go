@@ -3,6 +3,7 @@ package rcache
 import (
 	"fmt"
 	"os"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 
@@ -30,10 +31,28 @@ func DeleteOldCacheData(c redis.Conn) error {
 type Cache struct {
 	keyPrefix  string
 	ttlSeconds int
+
+	// version, if non-empty, is part of every key so that changing it
+	// logically flushes the namespace. See WithVersion.
+	version string
+
+	// compressMinSize is the size in bytes at which values are compressed
+	// before being written. Zero disables compression.
+	compressMinSize int
+
+	// local, if non-nil, serves reads and absorbs writes while Redis is
+	// unreachable.
+	local *localCache
+
+	// hotKeyMinHits and hotKeyMaxExtensions configure TTL extension of
+	// frequently read keys. See WithHotKeyExtension.
+	hotKeyMinHits       int
+	hotKeyMaxExtensions int
 }
 
 // New creates a redis backed Cache
 func New(keyPrefix string) *Cache {
+	registerNamespace(keyPrefix)
 	return &Cache{
 		keyPrefix: keyPrefix,
 	}
@@ -42,19 +61,47 @@ func New(keyPrefix string) *Cache {
 // NewWithTTL creates a redis backed Cache which expires values after
 // ttlSeconds.
 func NewWithTTL(keyPrefix string, ttlSeconds int) *Cache {
+	registerNamespace(keyPrefix)
 	return &Cache{
 		keyPrefix:  keyPrefix,
 		ttlSeconds: ttlSeconds,
 	}
 }
 
-func (r *Cache) GetMulti(keys ...string) [][]byte {
-	c := pool.Get()
-	defer c.Close()
+// WithVersion returns a copy of the cache whose keys include version. Bumping
+// the version when the shape of the cached values changes makes the cache
+// ignore values written by older code, without affecting other namespaces.
+// Values written under previous versions expire according to their TTL, or
+// are removed by Flush.
+func (r *Cache) WithVersion(version string) *Cache {
+	c := *r
+	c.version = version
+	return &c
+}
+
+// Flush deletes all values in the cache's namespace, across all versions.
+func (r *Cache) Flush() error {
+	return flushNamespace(r.keyPrefix)
+}
 
+func (r *Cache) GetMulti(keys ...string) [][]byte {
 	if len(keys) == 0 {
 		return nil
 	}
+	getLocal := func() [][]byte {
+		vals := make([][]byte, len(keys))
+		for i, key := range keys {
+			vals[i], _ = r.getLocal(key)
+		}
+		return vals
+	}
+	if r.skipRedis() {
+		return getLocal()
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
 	rkeys := make([]interface{}, len(keys))
 	for i, key := range keys {
 		rkeys[i] = r.rkeyPrefix() + key
@@ -63,10 +110,15 @@ func (r *Cache) GetMulti(keys ...string) [][]byte {
 	vals, err := redis.Values(c.Do("MGET", rkeys...))
 	if err != nil && err != redis.ErrNil {
 		log15.Warn("failed to execute redis command", "cmd", "MGET", "error", err)
+		if r.local != nil {
+			markRedisDown(err)
+			return getLocal()
+		}
 	}
 
 	strVals := make([][]byte, len(vals))
 	for i, val := range vals {
+		r.observeLookup(val != nil)
 		// MGET returns nil as not found.
 		if val == nil {
 			continue
@@ -77,19 +129,30 @@ func (r *Cache) GetMulti(keys ...string) [][]byte {
 			log15.Warn("failed to parse bytes from Redis value", "value", val)
 			continue
 		}
-		strVals[i] = b
+		if r.local != nil {
+			tierHitCounter.WithLabelValues("redis").Inc()
+		}
+		strVals[i] = decodeValue(b)
 	}
 	return strVals
 }
 
 func (r *Cache) SetMulti(keyvals ...[2]string) {
-	c := pool.Get()
-	defer c.Close()
-
 	if len(keyvals) == 0 {
 		return
 	}
 
+	ttl := r.ttl()
+	if r.skipRedis() {
+		for _, kv := range keyvals {
+			r.local.set(r.rkeyPrefix()+kv[0], []byte(kv[1]), ttl)
+		}
+		return
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
 	for _, kv := range keyvals {
 		k, v := kv[0], kv[1]
 		if !utf8.Valid([]byte(k)) {
@@ -100,39 +163,60 @@ func (r *Cache) SetMulti(keyvals ...[2]string) {
 			}
 			continue
 		}
-		if r.ttlSeconds == 0 {
-			if err := c.Send("SET", r.rkeyPrefix()+k, []byte(v)); err != nil {
+		b := r.encodeValue([]byte(v))
+		r.observeValueSize(b)
+		r.resetHits(c, k)
+		if ttl == 0 {
+			if err := c.Send("SET", r.rkeyPrefix()+k, b); err != nil {
 				log15.Warn("failed to write redis command to client output buffer", "cmd", "SET", "error", err)
 			}
 		} else {
-			if err := c.Send("SETEX", r.rkeyPrefix()+k, r.ttlSeconds, []byte(v)); err != nil {
+			if err := c.Send("SETEX", r.rkeyPrefix()+k, ttl, b); err != nil {
 				log15.Warn("failed to write redis command to client output buffer", "cmd", "SETEX", "error", err)
 			}
 		}
 	}
 	if err := c.Flush(); err != nil {
 		log15.Warn("failed to flush Redis client", "error", err)
+		if r.local != nil {
+			markRedisDown(err)
+			for _, kv := range keyvals {
+				r.local.set(r.rkeyPrefix()+kv[0], []byte(kv[1]), ttl)
+			}
+		}
 	}
 }
 
 // Get implements httpcache.Cache.Get
 func (r *Cache) Get(key string) ([]byte, bool) {
+	if r.skipRedis() {
+		return r.getLocal(key)
+	}
+
 	c := pool.Get()
 	defer c.Close()
 
 	b, err := redis.Bytes(c.Do("GET", r.rkeyPrefix()+key))
 	if err != nil && err != redis.ErrNil {
 		log15.Warn("failed to execute redis command", "cmd", "GET", "error", err)
+		if r.local != nil {
+			markRedisDown(err)
+			return r.getLocal(key)
+		}
 	}
+	if err == nil && r.local != nil {
+		tierHitCounter.WithLabelValues("redis").Inc()
+	}
+	if err == nil {
+		r.recordHit(c, key, r.ttl())
+	}
+	r.observeLookup(err == nil)
 
-	return b, err == nil
+	return decodeValue(b), err == nil
 }
 
 // Set implements httpcache.Cache.Set
 func (r *Cache) Set(key string, b []byte) {
-	c := pool.Get()
-	defer c.Close()
-
 	if !utf8.Valid([]byte(key)) {
 		if conf.IsDev(conf.DeployType()) {
 			panic(fmt.Sprintf("rcache: keys must be valid utf8 %v", []byte(key)))
@@ -141,21 +225,44 @@ func (r *Cache) Set(key string, b []byte) {
 		}
 	}
 
-	if r.ttlSeconds == 0 {
-		_, err := c.Do("SET", r.rkeyPrefix()+key, b)
+	ttl := r.ttl()
+	if r.skipRedis() {
+		r.local.set(r.rkeyPrefix()+key, b, ttl)
+		return
+	}
+
+	c := pool.Get()
+	defer c.Close()
+
+	encoded := r.encodeValue(b)
+	r.observeValueSize(encoded)
+
+	r.resetHits(c, key)
+	var err error
+	if ttl == 0 {
+		_, err = c.Do("SET", r.rkeyPrefix()+key, encoded)
 		if err != nil {
 			log15.Warn("failed to execute redis command", "cmd", "SET", "error", err)
 		}
 	} else {
-		_, err := c.Do("SETEX", r.rkeyPrefix()+key, r.ttlSeconds, b)
+		_, err = c.Do("SETEX", r.rkeyPrefix()+key, ttl, encoded)
 		if err != nil {
 			log15.Warn("failed to execute redis command", "cmd", "SETEX", "error", err)
 		}
 	}
+	if err != nil && r.local != nil {
+		markRedisDown(err)
+		r.local.set(r.rkeyPrefix()+key, b, ttl)
+	}
 }
 
 // Delete implements httpcache.Cache.Delete
 func (r *Cache) Delete(key string) {
+	if r.skipRedis() {
+		r.local.delete(r.rkeyPrefix() + key)
+		return
+	}
+
 	c := pool.Get()
 	defer c.Close()
 
@@ -163,10 +270,19 @@ func (r *Cache) Delete(key string) {
 	if err != nil {
 		log15.Warn("failed to execute redis command", "cmd", "DEL", "error", err)
 	}
+	if r.local != nil {
+		if err != nil {
+			markRedisDown(err)
+		}
+		r.local.delete(r.rkeyPrefix() + key)
+	}
 }
 
 // rkeyPrefix generates the actual key prefix we use on redis.
 func (r *Cache) rkeyPrefix() string {
+	if r.version != "" {
+		return fmt.Sprintf("%s:%s:%s:", globalPrefix, r.keyPrefix, r.version)
+	}
 	return fmt.Sprintf("%s:%s:", globalPrefix, r.keyPrefix)
 }
 
@@ -195,6 +311,8 @@ func SetupForTest(t TB) {
 	}
 
 	globalPrefix = "__test__" + t.Name()
+	atomic.StoreInt64(&redisDownUntil, 0)
+
 	c := pool.Get()
 	defer c.Close()
 
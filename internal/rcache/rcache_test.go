@@ -100,31 +100,31 @@ func TestCache_multi(t *testing.T) {
 	}
 
 	c.Set("k0", []byte("b"))
-	if got, exp := c.GetMulti("k0"), bytes("b"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k0"), byteSlices("b"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
 
 	c.SetMulti([2]string{"k0", "a"})
-	if got, exp := c.GetMulti("k0"), bytes("a"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k0"), byteSlices("a"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
 
 	c.SetMulti([2]string{"k0", "a"}, [2]string{"k1", "b"})
-	if got, exp := c.GetMulti("k0"), bytes("a"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k0"), byteSlices("a"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
-	if got, exp := c.GetMulti("k1"), bytes("b"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k1"), byteSlices("b"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
-	if got, exp := c.GetMulti("k0", "k1"), bytes("a", "b"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k0", "k1"), byteSlices("a", "b"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
-	if got, exp := c.GetMulti("k1", "k0"), bytes("b", "a"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k1", "k0"), byteSlices("b", "a"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
 
 	c.SetMulti([2]string{"k0", "x"}, [2]string{"k1", "y"}, [2]string{"k2", "z"})
-	if got, exp := c.GetMulti("k0", "k1", "k2"), bytes("x", "y", "z"); !reflect.DeepEqual(exp, got) {
+	if got, exp := c.GetMulti("k0", "k1", "k2"), byteSlices("x", "y", "z"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
 	got, exist := c.Get("k0")
@@ -175,12 +175,12 @@ func TestCache_deleteKeysWithPrefix(t *testing.T) {
 	}
 
 	vals = c.GetMulti(bKeys...)
-	if got, exp := vals, bytes("1", "3", "5", "7", "9"); !reflect.DeepEqual(exp, got) {
+	if got, exp := vals, byteSlices("1", "3", "5", "7", "9"); !reflect.DeepEqual(exp, got) {
 		t.Errorf("Expected %v, but got %v", exp, got)
 	}
 }
 
-func bytes(s ...string) [][]byte {
+func byteSlices(s ...string) [][]byte {
 	if s == nil {
 		return nil
 	}
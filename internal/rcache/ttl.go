@@ -0,0 +1,65 @@
+package rcache
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+var (
+	ttlOverridesMu sync.RWMutex
+	ttlOverrides   map[string]int
+)
+
+func init() {
+	go func() {
+		// Only changes to the setting itself are applied, so that unrelated
+		// changes to the site configuration do not reset overrides set in
+		// tests.
+		var last map[string]int
+		conf.Watch(func() {
+			ttls := conf.Get().CacheTtlSeconds
+			if reflect.DeepEqual(ttls, last) {
+				return
+			}
+			last = ttls
+			SetTTLOverrides(ttls)
+		})
+	}()
+}
+
+// SetTTLOverrides replaces the TTLs of cache namespaces. Each key matches the caches whose key
+// prefix starts with it (e.g., "gh_repo:" matches the caches of all GitHub clients), and the
+// longest matching key wins. Values written after the call expire after the overriding TTL (in
+// seconds) instead of the TTL the cache was created with. Non-positive TTLs are ignored.
+//
+// Every service which imports this package calls SetTTLOverrides whenever the cache.ttlSeconds site
+// setting changes, so that operators can tune cache freshness without a deploy.
+func SetTTLOverrides(ttls map[string]int) {
+	overrides := make(map[string]int, len(ttls))
+	for keyPrefix, ttl := range ttls {
+		if ttl > 0 {
+			overrides[keyPrefix] = ttl
+		}
+	}
+
+	ttlOverridesMu.Lock()
+	ttlOverrides = overrides
+	ttlOverridesMu.Unlock()
+}
+
+// ttl returns the TTL in seconds of values written to the cache, or 0 if they never expire.
+func (r *Cache) ttl() int {
+	ttlOverridesMu.RLock()
+	defer ttlOverridesMu.RUnlock()
+
+	ttl, matched := r.ttlSeconds, ""
+	for prefix, override := range ttlOverrides {
+		if strings.HasPrefix(r.keyPrefix, prefix) && len(prefix) >= len(matched) {
+			ttl, matched = override, prefix
+		}
+	}
+	return ttl
+}
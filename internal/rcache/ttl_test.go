@@ -0,0 +1,45 @@
+package rcache
+
+import "testing"
+
+func TestCache_ttl(t *testing.T) {
+	defer SetTTLOverrides(nil)
+
+	withTTL := NewWithTTL("with_ttl", 60)
+	withoutTTL := New("without_ttl")
+	versioned := withTTL.WithVersion("v2")
+
+	SetTTLOverrides(nil)
+	if got := withTTL.ttl(); got != 60 {
+		t.Errorf("got %d, want 60", got)
+	}
+	if got := withoutTTL.ttl(); got != 0 {
+		t.Errorf("got %d, want 0", got)
+	}
+
+	SetTTLOverrides(map[string]int{"with": 1, "with_ttl": 3600, "without_ttl": 10, "unknown": 5})
+	if got := withTTL.ttl(); got != 3600 {
+		t.Errorf("got %d, want 3600", got)
+	}
+	if got := versioned.ttl(); got != 3600 {
+		t.Errorf("versioned: got %d, want 3600", got)
+	}
+	if got := withoutTTL.ttl(); got != 10 {
+		t.Errorf("got %d, want 10", got)
+	}
+
+	// Overrides apply to all namespaces starting with the key.
+	SetTTLOverrides(map[string]int{"with": 1})
+	if got := withTTL.ttl(); got != 1 {
+		t.Errorf("got %d, want 1", got)
+	}
+
+	// Non-positive overrides are ignored.
+	SetTTLOverrides(map[string]int{"with_ttl": 0, "without_ttl": -1})
+	if got := withTTL.ttl(); got != 60 {
+		t.Errorf("got %d, want 60", got)
+	}
+	if got := withoutTTL.ttl(); got != 0 {
+		t.Errorf("got %d, want 0", got)
+	}
+}
@@ -52,20 +52,46 @@ func init() {
 	}
 }
 
+// connectTimeout bounds how long dialing a pooled connection may take, so that
+// commands fail quickly when Redis is unreachable but does not refuse
+// connections, rather than waiting for the OS TCP timeout.
+const connectTimeout = 3 * time.Second
+
 var schemeMatcher = lazyregexp.New(`^[A-Za-z][A-Za-z0-9\+\-\.]*://`)
 
-// dialRedis dials Redis given the raw endpoint string. The string can have two formats:
-// 1) If there is a HTTP scheme, it should be either be "redis://" or "rediss://" and the URL
+// dialRedis dials Redis given the raw endpoint string. The string can have three formats:
+// 1) If the scheme is "redis+sentinel://", the master is discovered through Redis Sentinel.
+//    See parseSentinelEndpoint for the format.
+// 2) If there is a HTTP scheme, it should be either be "redis://" or "rediss://" and the URL
 //    must be of the format specified in https://www.iana.org/assignments/uri-schemes/prov/redis.
-// 2) Otherwise, it is assumed to be of the format $HOSTNAME:$PORT.
-func dialRedis(rawEndpoint string) (redis.Conn, error) {
+// 3) Otherwise, it is assumed to be of the format $HOSTNAME:$PORT.
+func dialRedis(rawEndpoint string, opts ...redis.DialOption) (redis.Conn, error) {
+	if strings.HasPrefix(rawEndpoint, sentinelScheme) {
+		e, err := parseSentinelEndpoint(rawEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		return e.dial(opts...)
+	}
 	if schemeMatcher.MatchString(rawEndpoint) { // expect "redis://"
-		return redis.DialURL(rawEndpoint)
+		return redis.DialURL(rawEndpoint, opts...)
 	}
 	if strings.Contains(rawEndpoint, "/") {
 		return nil, errors.New("Redis endpoint without scheme should not contain '/'")
 	}
-	return redis.Dial("tcp", rawEndpoint)
+	return redis.Dial("tcp", rawEndpoint, opts...)
+}
+
+// DialCache dials a new connection to the Redis instance behind Cache. Unlike
+// connections borrowed from Cache, it uses the given options, e.g. timeouts.
+func DialCache(opts ...redis.DialOption) (redis.Conn, error) {
+	return dialRedis(addrCache, opts...)
+}
+
+// DialStore dials a new connection to the Redis instance behind Store. Unlike
+// connections borrowed from Store, it uses the given options, e.g. timeouts.
+func DialStore(opts ...redis.DialOption) (redis.Conn, error) {
+	return dialRedis(addrStore, opts...)
 }
 
 // Cache is a redis configured for caching. You usually want to use this. Only
@@ -78,7 +104,7 @@ var Cache = &redis.Pool{
 	MaxIdle:     3,
 	IdleTimeout: 240 * time.Second,
 	Dial: func() (redis.Conn, error) {
-		return dialRedis(addrCache)
+		return dialRedis(addrCache, redis.DialConnectTimeout(connectTimeout))
 	},
 	TestOnBorrow: func(c redis.Conn, t time.Time) error {
 		_, err := c.Do("PING")
@@ -98,6 +124,6 @@ var Store = &redis.Pool{
 		return err
 	},
 	Dial: func() (redis.Conn, error) {
-		return dialRedis(addrStore)
+		return dialRedis(addrStore, redis.DialConnectTimeout(connectTimeout))
 	},
 }
@@ -1,6 +1,13 @@
 package redispool
 
-import "testing"
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
 
 func TestSchemeMatcher(t *testing.T) {
 	tests := []struct {
@@ -20,3 +27,128 @@ func TestSchemeMatcher(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSentinelEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     *sentinelEndpoint
+		wantErr  bool
+	}{
+		{
+			endpoint: "redis+sentinel://sentinel:26379/mymaster",
+			want:     &sentinelEndpoint{sentinels: []string{"sentinel:26379"}, masterName: "mymaster"},
+		},
+		{
+			endpoint: "redis+sentinel://:secret@s1:26379,s2:26379,s3:26379/mymaster",
+			want:     &sentinelEndpoint{sentinels: []string{"s1:26379", "s2:26379", "s3:26379"}, masterName: "mymaster", password: "secret"},
+		},
+		{endpoint: "redis+sentinel://sentinel:26379", wantErr: true},
+		{endpoint: "redis+sentinel://sentinel:26379/", wantErr: true},
+		{endpoint: "redis+sentinel:///mymaster", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := parseSentinelEndpoint(test.endpoint)
+		if (err != nil) != test.wantErr {
+			t.Errorf("for endpoint %q, unexpected error: %v", test.endpoint, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("for endpoint %q, exp != got: %+v != %+v", test.endpoint, test.want, got)
+		}
+	}
+}
+
+func TestSentinelEndpoint_dialTimeouts(t *testing.T) {
+	old := sentinelTimeout
+	sentinelTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { sentinelTimeout = old })
+
+	// unresponsive accepts connections but never replies.
+	unresponsive := listen(t, nil)
+	master := listen(t, func(conn net.Conn) {
+		reply(conn, "*3\r\n$6\r\nmaster\r\n:0\r\n*0\r\n")
+	})
+	sentinelFor := func(addr string) string {
+		host, port, _ := net.SplitHostPort(addr)
+		return listen(t, func(conn net.Conn) {
+			reply(conn, fmt.Sprintf("*2\r\n$%d\r\n%s\r\n$%d\r\n%s\r\n", len(host), host, len(port), port))
+		})
+	}
+
+	t.Run("unresponsive sentinel is skipped", func(t *testing.T) {
+		e := &sentinelEndpoint{sentinels: []string{unresponsive, sentinelFor(master)}, masterName: "mymaster"}
+		start := time.Now()
+		c, err := e.dial()
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Close()
+		if d := time.Since(start); d > 2*time.Second {
+			t.Errorf("dial took %s", d)
+		}
+	})
+
+	t.Run("unresponsive master", func(t *testing.T) {
+		e := &sentinelEndpoint{sentinels: []string{sentinelFor(unresponsive)}, masterName: "mymaster"}
+		start := time.Now()
+		if _, err := e.dial(); err == nil {
+			t.Fatal("expected error")
+		}
+		if d := time.Since(start); d > 2*time.Second {
+			t.Errorf("dial took %s", d)
+		}
+	})
+}
+
+// listen starts a TCP server which calls serve with each accepted
+// connection, and returns its address. If serve is nil, connections are
+// accepted but never read from or written to.
+func listen(t *testing.T, serve func(net.Conn)) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		mu    sync.Mutex
+		conns []net.Conn
+	)
+	t.Cleanup(func() {
+		l.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	})
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			if serve != nil {
+				go serve(conn)
+			}
+		}
+	}()
+	return l.Addr().String()
+}
+
+// reply writes resp in response to every command read from conn.
+func reply(conn net.Conn, resp string) {
+	buf := make([]byte, 1024)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+		if _, err := conn.Write([]byte(resp)); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package redispool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+// sentinelScheme is the scheme of endpoints which are resolved through Redis
+// Sentinel rather than dialed directly.
+const sentinelScheme = "redis+sentinel://"
+
+// sentinelTimeout bounds the connect, read and write operations of each
+// sentinel query, and of the role check of the master it returns. Without it,
+// an unresponsive sentinel stalls every dial until the OS TCP timeout before
+// the next sentinel is tried.
+var sentinelTimeout = 3 * time.Second
+
+// sentinelEndpoint describes a Redis master discovered via Redis Sentinel.
+type sentinelEndpoint struct {
+	// sentinels are the $HOSTNAME:$PORT addresses of the sentinels to ask.
+	sentinels []string
+	// masterName is the name of the monitored master.
+	masterName string
+	// password is used to authenticate against the master.
+	password string
+}
+
+// parseSentinelEndpoint parses an endpoint of the form
+//
+//	redis+sentinel://[:password@]host1:26379[,host2:26379,...]/mastername
+func parseSentinelEndpoint(rawEndpoint string) (*sentinelEndpoint, error) {
+	rest := strings.TrimPrefix(rawEndpoint, sentinelScheme)
+
+	var password string
+	if i := strings.LastIndex(rest, "@"); i >= 0 {
+		password = strings.TrimPrefix(rest[:i], ":")
+		rest = rest[i+1:]
+	}
+
+	i := strings.Index(rest, "/")
+	if i < 0 || strings.Trim(rest[i+1:], "/") == "" {
+		return nil, errors.New("Redis Sentinel endpoint must specify the master name as its path")
+	}
+	masterName := strings.Trim(rest[i+1:], "/")
+
+	var sentinels []string
+	for _, addr := range strings.Split(rest[:i], ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			sentinels = append(sentinels, addr)
+		}
+	}
+	if len(sentinels) == 0 {
+		return nil, errors.New("Redis Sentinel endpoint must specify at least one sentinel address")
+	}
+
+	return &sentinelEndpoint{
+		sentinels:  sentinels,
+		masterName: masterName,
+		password:   password,
+	}, nil
+}
+
+// dial asks each sentinel in turn for the address of the current master and
+// connects to it. Sentinels that are unreachable or that do not know the
+// master are skipped, so a single sentinel failing does not break Redis
+// access. If a failover happens, the old master drops its client connections
+// and the pool dials again, which resolves the newly promoted master. The
+// master is dialed with opts.
+func (e *sentinelEndpoint) dial(opts ...redis.DialOption) (redis.Conn, error) {
+	var lastErr error
+	for _, sentinel := range e.sentinels {
+		addr, err := e.masterAddr(sentinel)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		masterOpts := []redis.DialOption{redis.DialConnectTimeout(sentinelTimeout)}
+		if e.password != "" {
+			masterOpts = append(masterOpts, redis.DialPassword(e.password))
+		}
+		c, err := redis.Dial("tcp", addr, append(masterOpts, opts...)...)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		// Guard against talking to a master which has since been demoted,
+		// e.g. when a sentinel has not yet observed a failover.
+		if err := checkRole(c, "master"); err != nil {
+			c.Close()
+			lastErr = err
+			continue
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("no Redis master %q found via sentinels %v: %v", e.masterName, e.sentinels, lastErr)
+}
+
+// masterAddr asks the sentinel at addr for the address of the master.
+func (e *sentinelEndpoint) masterAddr(sentinel string) (string, error) {
+	c, err := redis.Dial("tcp", sentinel,
+		redis.DialConnectTimeout(sentinelTimeout),
+		redis.DialReadTimeout(sentinelTimeout),
+		redis.DialWriteTimeout(sentinelTimeout),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer c.Close()
+
+	res, err := redis.Strings(c.Do("SENTINEL", "get-master-addr-by-name", e.masterName))
+	if err == redis.ErrNil {
+		return "", fmt.Errorf("sentinel %s does not know master %q", sentinel, e.masterName)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(res) != 2 {
+		return "", fmt.Errorf("sentinel %s returned unexpected master address %v", sentinel, res)
+	}
+	return res[0] + ":" + res[1], nil
+}
+
+// checkRole returns an error if the Redis instance c is connected to does not
+// report the expected replication role. The check is bounded by
+// sentinelTimeout without affecting later commands on c.
+func checkRole(c redis.Conn, want string) error {
+	values, err := redis.Values(redis.DoWithTimeout(c, sentinelTimeout, "ROLE"))
+	if err != nil {
+		return err
+	}
+	if len(values) == 0 {
+		return errors.New("empty ROLE response")
+	}
+	role, err := redis.String(values[0], nil)
+	if err != nil {
+		return err
+	}
+	if role != want {
+		return fmt.Errorf("Redis instance has role %q, expected %q", role, want)
+	}
+	return nil
+}
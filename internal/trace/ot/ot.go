@@ -6,6 +6,7 @@ package ot
 
 import (
 	"context"
+	"math/rand"
 	"net/http"
 	"strconv"
 	"strings"
@@ -39,6 +40,25 @@ func GetTracePolicy() tracePolicy {
 	return tracePolicy(trPolicy.Load())
 }
 
+var operationSampleRates atomic.Value // map[string]float64
+
+// SetOperationSampleRates sets the fraction (between 0 and 1) of traced requests for which spans of
+// each named operation are recorded. Spans of operations that are not in rates are always
+// recorded.
+func SetOperationSampleRates(rates map[string]float64) {
+	operationSampleRates.Store(rates)
+}
+
+// sampleOperation returns true if a span of the named operation should be recorded.
+func sampleOperation(operationName string) bool {
+	rates, _ := operationSampleRates.Load().(map[string]float64)
+	rate, ok := rates[operationName]
+	if !ok {
+		return true
+	}
+	return rand.Float64() < rate
+}
+
 // Middleware wraps the handler with the following:
 //
 // - If the HTTP header, X-Sourcegraph-Should-Trace, is set to a truthy value, set the
@@ -148,7 +168,12 @@ func StartSpanFromContext(ctx context.Context, operationName string, opts ...ope
 }
 
 // StartSpanFromContext starts a span using the tracer returned by invoking getTracer with the
-// passed-in tracer.
+// passed-in tracer. If the operation is not sampled (see SetOperationSampleRates), a no-op span is
+// returned along with the unmodified ctx, so that spans of nested operations are still attached to
+// the parent span.
 func StartSpanFromContextWithTracer(ctx context.Context, tracer opentracing.Tracer, operationName string, opts ...opentracing.StartSpanOption) (opentracing.Span, context.Context) {
+	if ShouldTrace(ctx) && !sampleOperation(operationName) {
+		return opentracing.NoopTracer{}.StartSpan(operationName), ctx
+	}
 	return opentracing.StartSpanFromContextWithTracer(ctx, getTracer(ctx, tracer), operationName, opts...)
 }
@@ -0,0 +1,37 @@
+package ot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestStartSpanFromContextWithTracer_operationSampleRates(t *testing.T) {
+	SetOperationSampleRates(map[string]float64{"skipped": 0, "sampled": 1})
+	defer SetOperationSampleRates(nil)
+
+	mt := mocktracer.New()
+	ctx := WithShouldTrace(context.Background(), true)
+
+	parent, ctx := StartSpanFromContextWithTracer(ctx, mt, "parent")
+	skipped, skippedCtx := StartSpanFromContextWithTracer(ctx, mt, "skipped")
+	if skippedCtx != ctx {
+		t.Error("expected context of skipped operation to be unmodified")
+	}
+	child, _ := StartSpanFromContextWithTracer(skippedCtx, mt, "sampled")
+	child.Finish()
+	skipped.Finish()
+	parent.Finish()
+
+	var names []string
+	for _, span := range mt.FinishedSpans() {
+		names = append(names, span.OperationName)
+	}
+	if len(names) != 2 || names[0] != "sampled" || names[1] != "parent" {
+		t.Fatalf("got finished spans %q, want [sampled parent]", names)
+	}
+	if got, want := mt.FinishedSpans()[0].ParentID, parent.Context().(mocktracer.MockSpanContext).SpanID; got != want {
+		t.Errorf("got parent span ID %d, want %d", got, want)
+	}
+}
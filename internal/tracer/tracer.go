@@ -96,6 +96,10 @@ func initTracer(serviceName string) {
 		// Set sampling strategy
 		samplingStrategy := ot.TraceNone
 		shouldLog := false
+		var (
+			operationSampleRates map[string]float64
+			redactedTags         []string
+		)
 		if tracingConfig := siteConfig.ObservabilityTracing; tracingConfig != nil {
 			switch tracingConfig.Sampling {
 			case "all":
@@ -104,9 +108,13 @@ func initTracer(serviceName string) {
 				samplingStrategy = ot.TraceSelective
 			}
 			shouldLog = tracingConfig.Debug
+			operationSampleRates = tracingConfig.OperationSampleRates
+			redactedTags = tracingConfig.RedactedTags
 		} else if siteConfig.UseJaeger {
 			samplingStrategy = ot.TraceAll
 		}
+		ot.SetOperationSampleRates(operationSampleRates)
+		globalTracer.setRedactedTags(redactedTags)
 		if tracePolicy := ot.GetTracePolicy(); tracePolicy != samplingStrategy && !initial {
 			log15.Info("opentracing: TracePolicy", "oldValue", tracePolicy, "newValue", samplingStrategy)
 		}
@@ -189,6 +197,7 @@ type switchableTracer struct {
 	tracer       opentracing.Tracer
 	tracerCloser io.Closer
 	log          bool
+	redactedTags map[string]struct{}
 }
 
 func newSwitchableTracer() *switchableTracer {
@@ -201,7 +210,22 @@ func (t *switchableTracer) StartSpan(operationName string, opts ...opentracing.S
 	if t.log {
 		log15.Info("opentracing: StartSpan", "operationName", operationName, "tracer", fmt.Sprintf("%T", t.tracer))
 	}
-	return t.tracer.StartSpan(operationName, opts...)
+	if len(t.redactedTags) == 0 {
+		return t.tracer.StartSpan(operationName, opts...)
+	}
+
+	// Tags may be set when starting the span as well as afterwards, so redact both.
+	var sso opentracing.StartSpanOptions
+	for _, o := range opts {
+		o.Apply(&sso)
+	}
+	for key := range sso.Tags {
+		if _, ok := t.redactedTags[key]; ok {
+			sso.Tags[key] = redactedTagValue
+		}
+	}
+	span := t.tracer.StartSpan(operationName, startSpanOptions(sso))
+	return &redactingSpan{Span: span, redactedTags: t.redactedTags}
 }
 
 func (t *switchableTracer) Inject(sm opentracing.SpanContext, format interface{}, carrier interface{}) error {
@@ -235,6 +259,18 @@ func (t *switchableTracer) set(tracer opentracing.Tracer, tracerCloser io.Closer
 	t.log = log
 }
 
+// setRedactedTags sets the names of span tags whose values are redacted.
+func (t *switchableTracer) setRedactedTags(tags []string) {
+	redactedTags := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		redactedTags[tag] = struct{}{}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.redactedTags = redactedTags
+}
+
 func (t *switchableTracer) get() (tracer opentracing.Tracer, log bool) {
 	t.mu.RLock()
 	defer t.mu.RUnlock()
@@ -242,3 +278,38 @@ func (t *switchableTracer) get() (tracer opentracing.Tracer, log bool) {
 }
 
 const tracingNotEnabledURL = "#tracing_not_enabled_for_this_request_add_?trace=1_to_url_to_enable"
+
+// redactedTagValue replaces the value of redacted span tags.
+const redactedTagValue = "REDACTED"
+
+// startSpanOptions is an opentracing.StartSpanOption that replaces all options with the ones it
+// holds.
+type startSpanOptions opentracing.StartSpanOptions
+
+func (o startSpanOptions) Apply(sso *opentracing.StartSpanOptions) {
+	*sso = opentracing.StartSpanOptions(o)
+}
+
+// redactingSpan wraps a span, replacing the values of the redacted tags before they are recorded.
+type redactingSpan struct {
+	opentracing.Span
+	redactedTags map[string]struct{}
+}
+
+func (s *redactingSpan) SetTag(key string, value interface{}) opentracing.Span {
+	if _, ok := s.redactedTags[key]; ok {
+		value = redactedTagValue
+	}
+	s.Span.SetTag(key, value)
+	return s
+}
+
+func (s *redactingSpan) SetOperationName(operationName string) opentracing.Span {
+	s.Span.SetOperationName(operationName)
+	return s
+}
+
+func (s *redactingSpan) SetBaggageItem(restrictedKey, value string) opentracing.Span {
+	s.Span.SetBaggageItem(restrictedKey, value)
+	return s
+}
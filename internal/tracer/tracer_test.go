@@ -0,0 +1,41 @@
+package tracer
+
+import (
+	"testing"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+)
+
+func TestSwitchableTracer_redactedTags(t *testing.T) {
+	mt := mocktracer.New()
+	tr := newSwitchableTracer()
+	tr.set(mt, nil, false)
+	tr.setRedactedTags([]string{"Argument"})
+
+	span := tr.StartSpan("op", opentracing.Tag{Key: "Argument", Value: "secret/path.go"}, opentracing.Tag{Key: "Server", Value: "Repos"})
+	span.SetTag("Argument", "other/secret.go").SetTag("Method", "Get")
+	span.Finish()
+
+	spans := mt.FinishedSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d finished spans, want 1", len(spans))
+	}
+	want := map[string]interface{}{
+		"Argument": redactedTagValue,
+		"Server":   "Repos",
+		"Method":   "Get",
+	}
+	for key, value := range want {
+		if got := spans[0].Tag(key); got != value {
+			t.Errorf("tag %q: got %v, want %v", key, got, value)
+		}
+	}
+
+	// Without redacted tags, tags are recorded unchanged.
+	tr.setRedactedTags(nil)
+	tr.StartSpan("op", opentracing.Tag{Key: "Argument", Value: "a.go"}).Finish()
+	if got := mt.FinishedSpans()[1].Tag("Argument"); got != "a.go" {
+		t.Errorf("got %v, want %q", got, "a.go")
+	}
+}
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS security_event_logs;
+DROP FUNCTION IF EXISTS security_event_logs_prevent_update();
+
+COMMIT;
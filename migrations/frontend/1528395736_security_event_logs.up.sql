@@ -0,0 +1,27 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS security_event_logs (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    user_id integer NOT NULL,
+    argument jsonb NOT NULL DEFAULT '{}'::jsonb,
+    version text NOT NULL,
+    "timestamp" timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT security_event_logs_check_name_not_empty CHECK (name <> ''::text)
+);
+
+CREATE INDEX IF NOT EXISTS security_event_logs_timestamp ON security_event_logs USING btree ("timestamp");
+CREATE INDEX IF NOT EXISTS security_event_logs_user_id ON security_event_logs USING btree (user_id);
+
+-- Security event logs are append-only: rows may be deleted once they fall out
+-- of the retention window, but never modified.
+CREATE OR REPLACE FUNCTION security_event_logs_prevent_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'security_event_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS security_event_logs_prevent_update ON security_event_logs;
+CREATE TRIGGER security_event_logs_prevent_update BEFORE UPDATE ON security_event_logs FOR EACH ROW EXECUTE PROCEDURE security_event_logs_prevent_update();
+
+COMMIT;
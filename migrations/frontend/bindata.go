@@ -104,6 +104,8 @@
 // 1528395734_repo_updater_log_contents.up.sql (565B)
 // 1528395735_drop_language_from_repo.down.sql (74B)
 // 1528395735_drop_language_from_repo.up.sql (66B)
+// 1528395736_security_event_logs.down.sql (121B)
+// 1528395736_security_event_logs.up.sql (1.127kB)
 
 package migrations
 
@@ -2252,6 +2254,46 @@ func _1528395735_drop_language_from_repoUpSql() (*asset, error) {
 	return a, nil
 }
 
+var __1528395736_security_event_logsDownSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x00\x79\x00\x86\xff\x42\x45\x47\x49\x4e\x3b\x0a\x0a\x44\x52\x4f\x50\x20\x54\x41\x42\x4c\x45\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x63\x75\x72\x69\x74\x79\x5f\x65\x76\x65\x6e\x74\x5f\x6c\x6f\x67\x73\x3b\x0a\x44\x52\x4f\x50\x20\x46\x55\x4e\x43\x54\x49\x4f\x4e\x20\x49\x46\x20\x45\x58\x49\x53\x54\x53\x20\x73\x65\x63\x75\x72\x69\x74\x79\x5f\x65\x76\x65\x6e\x74\x5f\x6c\x6f\x67\x73\x5f\x70\x72\x65\x76\x65\x6e\x74\x5f\x75\x70\x64\x61\x74\x65\x28\x29\x3b\x0a\x0a\x43\x4f\x4d\x4d\x49\x54\x3b\x0a\x03\x00\x58\x2d\x8d\x19\x79\x00\x00\x00")
+
+func _1528395736_security_event_logsDownSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_security_event_logsDownSql,
+		"1528395736_security_event_logs.down.sql",
+	)
+}
+
+func _1528395736_security_event_logsDownSql() (*asset, error) {
+	bytes, err := _1528395736_security_event_logsDownSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_security_event_logs.down.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x97, 0xd7, 0xd5, 0x4c, 0x2f, 0x96, 0x8b, 0x45, 0x45, 0xab, 0xef, 0x20, 0x33, 0x31, 0x8b, 0xac, 0xe3, 0xb3, 0xe8, 0xc5, 0x2, 0xd7, 0xff, 0x8b, 0x25, 0x2a, 0xd3, 0x11, 0x22, 0xdc, 0x1c, 0xcf}}
+	return a, nil
+}
+
+var __1528395736_security_event_logsUpSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x53\xef\x6e\xda\x4e\x10\xfc\xee\xa7\x18\x45\x48\x80\x14\x7e\x0f\x00\x3f\x55\x72\xcc\x42\xac\x10\x1b\x9d\xcf\x2a\xf9\x64\x19\xbc\x21\xd7\xda\x77\xee\xf9\x08\xa5\x55\xdf\xbd\xb2\xc9\xbf\xb6\xb4\xa5\xdf\x7c\xbb\xab\x99\x9d\x99\xf5\x15\xcd\xc3\x68\xe2\x79\x81\x20\x5f\x12\xa4\x7f\xb5\x20\x84\x33\x44\xb1\x04\xad\xc2\x44\x26\x68\x78\xb3\xb3\xca\x1d\x32\x7e\x64\xed\xb2\xd2\x6c\x1b\x0c\x3c\x00\x50\x05\xd6\x6a\xdb\xb0\x55\x79\x89\xa5\x08\x6f\x7d\x71\x87\x1b\xba\xbb\xec\xba\x3a\xaf\x18\x8e\x3f\xbb\x0e\x2c\x4a\x17\x8b\x63\x7d\xd7\xb0\xcd\x54\x01\xa5\x1d\x6f\xd9\xfe\xd4\xcd\xed\x76\x57\xb1\x76\xf8\xd0\x18\xbd\x7e\x69\x62\x4a\x33\x3f\x5d\x48\xf4\xbf\x7e\xeb\x8f\xc7\x5d\xf3\x08\xf7\xc8\xb6\x51\x46\x9f\x62\xba\x70\xaa\xe2\xc6\xe5\x55\x7d\x81\x97\x4f\xec\x95\x7b\xe8\x9e\xf8\x62\x34\xff\xca\xa0\xcd\x7e\x30\x3c\x02\x04\x71\x94\x48\xe1\x87\x91\x3c\xe5\x42\xb6\x79\xe0\xcd\xc7\xac\xd5\x99\x69\xe3\x32\xae\x6a\x77\x40\x70\x4d\xc1\x0d\x06\x6d\x15\xff\xbf\x43\xbf\x3f\x1e\xb7\xab\x0d\xbd\xe1\xab\xcd\x61\x34\xa5\xd5\xdf\x6d\xce\x5e\x97\x8e\xa3\x53\x03\x48\x93\x30\x9a\x63\xed\x2c\x33\x06\x6f\xe4\x0e\x27\xff\x4a\xf5\x9c\xca\x39\x44\x4f\xb3\xad\xa0\xd1\x08\xc9\xd3\x38\xba\x71\x74\xe3\xb9\x65\xe4\x75\xcd\xba\x18\x19\x5d\x1e\xc6\xb0\x66\xdf\xa0\xca\x0f\x58\x33\x0a\x2e\xd9\x71\x01\xa3\x37\x0c\xf7\xc0\x07\xdc\xe7\x65\x09\xb3\x73\xde\x68\x04\x73\xdf\xd6\x60\xd9\xb1\x76\x6d\xb0\x7b\xa5\x0b\xb3\xbf\xc4\x7a\xe7\xa0\xf9\x91\x2d\x2a\x53\xa8\x7b\xc5\xc5\x7f\xcf\x22\x63\x01\x41\xcb\x85\x1f\x10\x66\x69\x14\xc8\xf0\xb4\x8a\xac\xb6\xc7\xc7\xae\x2e\x72\xc7\x83\x21\x04\xc9\x54\x44\x09\x9c\x55\xdb\xf6\x16\xfd\x04\xbd\x9e\xd7\xfd\x14\xdd\x01\x08\x3f\x4c\x08\xb4\x0a\x68\xd9\x81\xf6\x4f\x79\xa3\x9a\xb7\x5a\xfb\x13\x8f\xa2\xe9\xc4\xeb\xf5\xb0\xf0\xa3\x79\xea\xcf\x09\x75\x59\x6f\x9b\x4f\xe5\xc4\xf3\xa6\x22\x5e\x42\x8a\x70\x3e\x27\xd1\x1e\xc0\x1f\x12\xf9\x71\xd9\xdf\x04\xf3\x92\xf3\x33\xe6\x19\x48\x57\x34\x8b\x05\x21\x5d\x4e\x3b\xef\x4e\xe2\x62\x16\x0b\x90\x1f\x5c\x43\xc4\xef\x41\x2b\x0a\x52\x49\x58\x8a\x38\xa0\x69\x2a\xe8\x0c\x9a\x41\x7b\x1e\x41\x7c\x7b\x1b\xca\x89\xf7\x7d\x00\xde\x4d\xbd\x91\x67\x04\x00\x00")
+
+func _1528395736_security_event_logsUpSqlBytes() ([]byte, error) {
+	return bindataRead(
+		__1528395736_security_event_logsUpSql,
+		"1528395736_security_event_logs.up.sql",
+	)
+}
+
+func _1528395736_security_event_logsUpSql() (*asset, error) {
+	bytes, err := _1528395736_security_event_logsUpSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "1528395736_security_event_logs.up.sql", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x74, 0x92, 0xbe, 0x3c, 0x5b, 0x6a, 0x90, 0xa2, 0xfe, 0x47, 0x82, 0x6c, 0x7e, 0xf6, 0xcc, 0xf, 0x18, 0x60, 0x74, 0x72, 0x9a, 0xa0, 0x35, 0x8f, 0x18, 0xa9, 0x8, 0x68, 0x97, 0x1e, 0xa4, 0x70}}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -2447,6 +2489,8 @@ var _bindata = map[string]func() (*asset, error){
 	"1528395734_repo_updater_log_contents.up.sql":                                  _1528395734_repo_updater_log_contentsUpSql,
 	"1528395735_drop_language_from_repo.down.sql":                                  _1528395735_drop_language_from_repoDownSql,
 	"1528395735_drop_language_from_repo.up.sql":                                    _1528395735_drop_language_from_repoUpSql,
+	"1528395736_security_event_logs.down.sql":                                      _1528395736_security_event_logsDownSql,
+	"1528395736_security_event_logs.up.sql":                                        _1528395736_security_event_logsUpSql,
 }
 
 // AssetDebug is true if the assets were built with the debug flag enabled.
@@ -2597,6 +2641,8 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"1528395734_repo_updater_log_contents.up.sql":                                  {_1528395734_repo_updater_log_contentsUpSql, map[string]*bintree{}},
 	"1528395735_drop_language_from_repo.down.sql":                                  {_1528395735_drop_language_from_repoDownSql, map[string]*bintree{}},
 	"1528395735_drop_language_from_repo.up.sql":                                    {_1528395735_drop_language_from_repoUpSql, map[string]*bintree{}},
+	"1528395736_security_event_logs.down.sql":                                      {_1528395736_security_event_logsDownSql, map[string]*bintree{}},
+	"1528395736_security_event_logs.up.sql":                                        {_1528395736_security_event_logsUpSql, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory.
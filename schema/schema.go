@@ -429,6 +429,12 @@ type ExperimentalFeatures struct {
 	DebugLog *DebugLog `json:"debug.log,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// LanguageStatisticsExcludeGenerated description: Exclude generated files (such as `*.pb.go`, lockfiles, and files marked with a "Code generated ... DO NOT EDIT." header) when computing language statistics.
+	LanguageStatisticsExcludeGenerated *bool `json:"languageStatistics.excludeGenerated,omitempty"`
+	// LanguageStatisticsExcludePaths description: A map from repository name to a list of paths (relative to the repository root) to exclude when computing the repository's language statistics. Excluding a directory excludes everything beneath it. Vendored directories and generated files are excluded separately (see `languageStatistics.excludeVendored` and `languageStatistics.excludeGenerated`).
+	LanguageStatisticsExcludePaths map[string][]string `json:"languageStatistics.excludePaths,omitempty"`
+	// LanguageStatisticsExcludeVendored description: Exclude vendored directories and files (such as `vendor/` and `node_modules/`) when computing language statistics.
+	LanguageStatisticsExcludeVendored *bool `json:"languageStatistics.excludeVendored,omitempty"`
 	// SearchIndexBranches description: A map from repository name to a list of extra revs (branch, ref, tag, commit sha, etc) to index for a repository. We always index the default branch ("HEAD") and revisions in version contexts. This allows specifying additional revisions. Sourcegraph can index up to 64 branches per repository.
 	SearchIndexBranches map[string][]string `json:"search.index.branches,omitempty"`
 	// SearchMultipleRevisionsPerRepository description: DEPRECATED. Always on. Will be removed in 3.19.
@@ -852,6 +858,10 @@ type ObservabilityAlerts struct {
 type ObservabilityTracing struct {
 	// Debug description: Turns on debug logging of opentracing client requests. This can be useful for debugging connectivity issues between the tracing client and the Jaeger agent, the performance overhead of tracing, and other issues related to the use of distributed tracing.
 	Debug bool `json:"debug,omitempty"`
+	// OperationSampleRates description: A map from operation (span) name to the fraction (between 0 and 1) of traced requests for which spans of that operation are recorded. Operations that are not listed are always recorded. Use this to keep tracing enabled on high-traffic instances without recording every call of a frequent operation.
+	OperationSampleRates map[string]float64 `json:"operationSampleRates,omitempty"`
+	// RedactedTags description: Names of span tags whose values are replaced with "REDACTED" before they are sent to the tracing backend, such as tags that may contain file paths or other sensitive data.
+	RedactedTags []string `json:"redactedTags,omitempty"`
 	// Sampling description: Determines the requests for which distributed traces are recorded. "none" (default) turns off tracing entirely. "selective" sends traces whenever `?trace=1` is present in the URL. "all" sends traces on every request. Note that this only affects the behavior of the distributed tracing client. The Jaeger instance must be running for traces to be collected (as described in the Sourcegraph installation instructions). Additional downsampling can be configured in Jaeger, itself (https://www.jaegertracing.io/docs/1.17/sampling)
 	Sampling string `json:"sampling,omitempty"`
 }
@@ -1151,6 +1161,8 @@ type SiteConfiguration struct {
 	//
 	// Only available in Sourcegraph Enterprise.
 	Branding *Branding `json:"branding,omitempty"`
+	// CacheTtlSeconds description: A map from cache namespace to the number of seconds after which values written to it expire. A key matches every Redis cache whose key prefix starts with it (e.g. "gh_repo:" matches the repository caches of all GitHub connections), and the longest matching key wins. Overrides the TTL the cache was created with, so that freshness can be traded off against load without a deploy. Changes apply to values written after the change.
+	CacheTtlSeconds map[string]int `json:"cache.ttlSeconds,omitempty"`
 	// CampaignsEnabled description: Enables/disables the campaigns feature.
 	CampaignsEnabled *bool `json:"campaigns.enabled,omitempty"`
 	// CampaignsReadAccessEnabled description: DEPRECATED: Enables read-only access to campaigns for non-site-admin users. This doesn't have an effect anymore.
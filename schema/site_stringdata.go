@@ -172,6 +172,31 @@ const SiteSchemaJSON = `{
             }
           ]
         },
+        "languageStatistics.excludeVendored": {
+          "description": "Exclude vendored directories and files (such as ` + "`" + `vendor/` + "`" + ` and ` + "`" + `node_modules/` + "`" + `) when computing language statistics.",
+          "type": "boolean",
+          "default": true,
+          "!go": { "pointer": true }
+        },
+        "languageStatistics.excludeGenerated": {
+          "description": "Exclude generated files (such as ` + "`" + `*.pb.go` + "`" + `, lockfiles, and files marked with a \"Code generated ... DO NOT EDIT.\" header) when computing language statistics.",
+          "type": "boolean",
+          "default": true,
+          "!go": { "pointer": true }
+        },
+        "languageStatistics.excludePaths": {
+          "description": "A map from repository name to a list of paths (relative to the repository root) to exclude when computing the repository's language statistics. Excluding a directory excludes everything beneath it. Vendored directories and generated files are excluded separately (see ` + "`" + `languageStatistics.excludeVendored` + "`" + ` and ` + "`" + `languageStatistics.excludeGenerated` + "`" + `).",
+          "type": "object",
+          "additionalProperties": {
+            "type": "array",
+            "items": { "type": "string" }
+          },
+          "examples": [
+            {
+              "github.com/sourcegraph/sourcegraph": ["docker-images", "dev/ci"]
+            }
+          ]
+        },
         "versionContexts": {
           "description": "JSON array of version context configuration",
           "type": "array",
@@ -271,6 +296,16 @@ const SiteSchemaJSON = `{
       "!go": { "pointer": true },
       "group": "Campaigns"
     },
+    "cache.ttlSeconds": {
+      "description": "A map from cache namespace to the number of seconds after which values written to it expire. A key matches every Redis cache whose key prefix starts with it (e.g. \"gh_repo:\" matches the repository caches of all GitHub connections), and the longest matching key wins. Overrides the TTL the cache was created with, so that freshness can be traded off against load without a deploy. Changes apply to values written after the change.",
+      "type": "object",
+      "additionalProperties": {
+        "type": "integer",
+        "minimum": 1
+      },
+      "group": "Misc.",
+      "examples": [{ "gh_repo:": 3600, "search_results_stats": 600 }]
+    },
     "corsOrigin": {
       "description": "Required when using any of the native code host integrations for Phabricator, GitLab, or Bitbucket Server. It is a space-separated list of allowed origins for cross-origin HTTP requests which should be the base URL for your Phabricator, GitLab, or Bitbucket Server instance.",
       "type": "string",
@@ -646,6 +681,22 @@ const SiteSchemaJSON = `{
           "description": "Turns on debug logging of opentracing client requests. This can be useful for debugging connectivity issues between the tracing client and the Jaeger agent, the performance overhead of tracing, and other issues related to the use of distributed tracing.",
           "type": "boolean",
           "default": false
+        },
+        "operationSampleRates": {
+          "description": "A map from operation (span) name to the fraction (between 0 and 1) of traced requests for which spans of that operation are recorded. Operations that are not listed are always recorded. Use this to keep tracing enabled on high-traffic instances without recording every call of a frequent operation.",
+          "type": "object",
+          "additionalProperties": {
+            "type": "number",
+            "minimum": 0,
+            "maximum": 1
+          },
+          "examples": [{ "Repos.GetInventory": 0.1, "Repos.ResolveRev": 0 }]
+        },
+        "redactedTags": {
+          "description": "Names of span tags whose values are replaced with \"REDACTED\" before they are sent to the tracing backend, such as tags that may contain file paths or other sensitive data.",
+          "type": "array",
+          "items": { "type": "string" },
+          "examples": [["Argument"]]
         }
       }
     },